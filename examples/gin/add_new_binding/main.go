@@ -1,29 +1,60 @@
 package main
 
 import (
+	"io"
+	"reflect"
+
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 
 	"github.com/fioepq9/helper"
 )
 
+// TextBinding binds the text/plain body into the string fields with tag text
+type TextBinding struct{}
+
+var _ helper.GinBodyBinding = TextBinding{}
+
+func (TextBinding) Name() string {
+	return "text"
+}
+
+// MIMETypes makes the binding only run for text/plain requests
+func (TextBinding) MIMETypes() []string {
+	return []string{binding.MIMEPlain}
+}
+
+func (TextBinding) Bind(c *gin.Context, obj any) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(obj).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if _, ok := v.Type().Field(i).Tag.Lookup("text"); ok {
+			v.Field(i).SetString(string(body))
+		}
+	}
+	return nil
+}
+
 type EchoRequest struct {
-	Message string `yaml:"message"`
+	Message string `text:"message"`
 }
 
 type EchoResponse struct {
 	Message string `json:"message"`
 }
 
-// curl -X POST "http://localhost:8080/echo" -d 'message: hello'
+// curl -X POST "http://localhost:8080/echo" -H 'Content-Type: text/plain' -d 'hello'
 // {"message":"hello"}
 func main() {
 	e := gin.New()
 
-	// add yaml
+	// add text
 	r := helper.
 		Gin(func(ginHelper *helper.GinHelper) {
-			ginHelper.Bindings = append(ginHelper.Bindings, helper.NewGinBinding(binding.YAML))
+			ginHelper.Bindings = append(ginHelper.Bindings, TextBinding{})
 		}).
 		Router(e)
 
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/fioepq9/helper"
+)
+
+type GetUserRequest struct {
+	ID     int      `uri:"id"`
+	Token  string   `header:"X-Token"`
+	Fields []string `form:"fields"`
+	Theme  string   `cookie:"theme"`
+}
+
+type UpdateUserRequest struct {
+	ID   int    `uri:"id"`
+	Name string `json:"name" binding:"required"`
+}
+
+type LoginRequest struct {
+	Username string `form:"username" binding:"required"`
+	Remember bool   `form:"remember"`
+}
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Register registers the user routes on r, the users are kept in memory
+func Register(r *helper.GinRouter) {
+	var mu sync.Mutex
+	users := map[int]*User{1: {ID: 1, Name: "alice"}}
+
+	r.GET("/users/:id", func(c *gin.Context, req *GetUserRequest) (*User, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		user, ok := users[req.ID]
+		if !ok {
+			return nil, helper.NewHTTPError(http.StatusNotFound, "user not found")
+		}
+		return user, nil
+	})
+	r.PUT("/users/:id", func(c *gin.Context, req *UpdateUserRequest) (*User, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		users[req.ID] = &User{ID: req.ID, Name: req.Name}
+		return users[req.ID], nil
+	})
+	r.POST("/login", func(c *gin.Context, req *LoginRequest) error {
+		return nil
+	})
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/examples/gin/client/api"
+)
+
+// go run . -client client.go
+// writes the typed client of the routes, which calls them with the request types of package api
+//
+// go run .
+// curl -X GET "http://localhost:8080/users/1"
+// {"id":1,"name":"alice"}
+func main() {
+	client := flag.String("client", "", "write the generated client to the file")
+	flag.Parse()
+
+	e := gin.New()
+
+	r := helper.Gin().Router(e)
+
+	api.Register(r)
+
+	if *client != "" {
+		src, err := r.GenerateClient(func(g *helper.GinClientGenerator) {
+			g.Package = "main"
+			g.Name = "UsersClient"
+		})
+		if err != nil {
+			panic(err)
+		}
+		if err := os.WriteFile(*client, src, 0o644); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	if err := e.Run(":8080"); err != nil {
+		panic(err)
+	}
+}
@@ -1,20 +1,17 @@
 package helper
 
 import (
-	"bytes"
 	"net/http"
+	"path"
 	"reflect"
+	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/locales"
-	"github.com/go-playground/locales/zh"
-	ut "github.com/go-playground/universal-translator"
-	validator "github.com/go-playground/validator/v10"
-	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
 	"github.com/rs/zerolog"
 )
 
@@ -29,32 +26,61 @@ type GinHelper struct {
 	BindingErrorHandler func(*gin.Context, error)
 	SuccessHandler      func(*gin.Context, any)
 	ErrorHandler        func(*gin.Context, error)
+	// Envelope wraps the responses of the default handlers, nil to disable
+	Envelope *GinEnvelope
+	// Offers are the response formats the default handlers negotiate on Accept, e.g. GinRenderOffers,
+	// nil to respond the first of GinDefaultOffers whatever the Accept is
+	Offers []string
+	// StreamHeartbeat is the heartbeat interval of the Server-Sent Events, 0 to disable
+	StreamHeartbeat time.Duration
+	// Providers provide the handler arguments by type, see Provide
+	Providers map[reflect.Type]GinProvider
+	// Logger is used when the request context has no logger, nil to use the global logger of zerolog/log
+	Logger *zerolog.Logger
+	// ErrorInterceptors run in order on the handler errors before the ErrorHandler, each returns the error for the next,
+	// nil if it has handled the error, e.g. func(c *gin.Context, err error) error { map gorm.ErrRecordNotFound to 404 }
+	ErrorInterceptors []func(c *gin.Context, err error) error
 }
 
-// Gin
-//   - Notes: This function first call will disable the gin default validator
+// NewGinHelper returns an independent helper, the options are applied after the defaults
+func NewGinHelper(options ...func(*GinHelper)) *GinHelper {
+	h := &GinHelper{
+		Bindings: []GinBinding{
+			NewGinDefaultBinding(),
+			NewGinDecodeBinding(binding.Header),
+			NewGinCookieBinding(),
+			NewGinURIBinding(func(b *GinURIBinding) {
+				b.BindingURI = nil
+			}),
+			NewGinDecodeBinding(binding.Query),
+			NewGinDecodeBinding(binding.Form),
+			NewGinFileBinding(),
+			NewGinDecodeBinding(binding.JSON),
+			NewGinDecodeBinding(binding.XML),
+			NewGinDecodeBinding(binding.YAML),
+			NewGinDecodeBinding(binding.TOML),
+			NewGinDecodeBinding(binding.MsgPack),
+		},
+		BindingValidator: NewGinValidator(RegisterPaginationValidation),
+		StreamHeartbeat:  15 * time.Second,
+	}
+	h.Providers = defaultGinProviders(h)
+	h.BindingErrorHandler = h.DefaultBindingErrorHandler
+	h.ErrorHandler = h.DefaultErrorHandler
+	h.SuccessHandler = h.DefaultSuccessHandler
+
+	for _, opt := range options {
+		opt(h)
+	}
+
+	return h
+}
+
+// Gin returns the default helper, the options change it for all its users.
+// Use NewGinHelper for an independent one.
 func Gin(options ...func(*GinHelper)) *GinHelper {
 	ginHelperOnce.Do(func() {
-		ginHelper = &GinHelper{
-			Bindings: []GinBinding{
-				NewGinDefaultBinding(),
-				NewGinBinding(binding.Header),
-				NewGinURIBinding(),
-				NewGinBinding(binding.Form),
-				NewGinBinding(binding.JSON),
-			},
-			BindingValidator: NewGinValidator(),
-			BindingErrorHandler: func(c *gin.Context, err error) {
-				c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
-			},
-			ErrorHandler: func(c *gin.Context, err error) {
-				c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
-			},
-			SuccessHandler: func(c *gin.Context, resp any) {
-				c.JSON(http.StatusOK, resp)
-			},
-		}
-		gin.DisableBindValidation()
+		ginHelper = NewGinHelper()
 	})
 	for _, opt := range options {
 		opt(ginHelper)
@@ -62,6 +88,92 @@ func Gin(options ...func(*GinHelper)) *GinHelper {
 	return ginHelper
 }
 
+// DefaultBindingErrorHandler responds the HTTPError found in err,
+// the field errors of ValidationError or err itself with 400
+func (h *GinHelper) DefaultBindingErrorHandler(c *gin.Context, err error) {
+	var (
+		httpErr *HTTPError
+		verr    *ValidationError
+	)
+	switch {
+	case errors.As(err, &httpErr):
+	case errors.As(err, &verr):
+		httpErr = WrapHTTPError(err, http.StatusBadRequest, http.StatusText(http.StatusBadRequest), func(e *HTTPError) {
+			e.Details = verr.Fields
+		})
+	default:
+		httpErr = NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	h.abortWithHTTPError(c, httpErr)
+}
+
+// DefaultErrorHandler responds the HTTPError found in err, or 500 with a generic message
+func (h *GinHelper) DefaultErrorHandler(c *gin.Context, err error) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = NewHTTPError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+	h.abortWithHTTPError(c, httpErr)
+}
+
+// DefaultSuccessHandler responds resp with the status set by c.Status, the route Status or 200 in the format negotiated on Accept,
+// or 406 if no offered format is acceptable
+func (h *GinHelper) DefaultSuccessHandler(c *gin.Context, resp any) {
+	status := http.StatusOK
+	if route, ok := GinRouteFromContext(c); ok && route.Status != 0 {
+		status = route.Status
+	}
+	// the status set by c.Status, e.g. in BeforeRender, takes precedence
+	if !c.Writer.Written() && c.Writer.Status() != http.StatusOK {
+		status = c.Writer.Status()
+	}
+	if status == http.StatusNoContent {
+		c.Status(status)
+		return
+	}
+	format := h.negotiate(c)
+	if format == "" {
+		h.abortWithHTTPError(c, NewHTTPError(http.StatusNotAcceptable, http.StatusText(http.StatusNotAcceptable)))
+		return
+	}
+	if envelope, ok := h.envelope(c); ok {
+		h.render(c, status, format, envelope.Wrap(c, envelope.SuccessCode, envelope.SuccessMessage, resp))
+		return
+	}
+	h.render(c, status, format, resp)
+}
+
+// abortWithHTTPError writes the headers of e and aborts with its status and body,
+// the body falls back to the first offered format if no one is acceptable
+func (h *GinHelper) abortWithHTTPError(c *gin.Context, e *HTTPError) {
+	for k, vs := range e.Header {
+		for _, v := range vs {
+			c.Writer.Header().Add(k, v)
+		}
+	}
+	format := h.negotiate(c)
+	if format == "" {
+		format = h.offers(c)[0]
+	}
+	c.Abort()
+	if envelope, ok := h.envelope(c); ok {
+		h.render(c, e.Status, format, envelope.Wrap(c, e.Code, e.Message, e.Details))
+		return
+	}
+	h.render(c, e.Status, format, e)
+}
+
+// envelope returns the envelope of the helper unless the route of c disables it
+func (h *GinHelper) envelope(c *gin.Context) (*GinEnvelope, bool) {
+	if h.Envelope == nil {
+		return nil, false
+	}
+	if route, ok := GinRouteFromContext(c); ok && route.DisableEnvelope {
+		return nil, false
+	}
+	return h.Envelope, true
+}
+
 // SetZerologWriter set zerolog writer
 //   - gin.DefaultWriter
 //   - gin.DefaultErrorWriter
@@ -74,224 +186,386 @@ func (h *GinHelper) SetZerologWriter(log zerolog.Logger, lvl zerolog.Level) *Gin
 
 func (h *GinHelper) Router(routes gin.IRoutes) *GinRouter {
 	return &GinRouter{
-		helper: h,
-		routes: routes,
+		helper:   h,
+		routes:   routes,
+		registry: &ginRegistry{},
 	}
 }
 
 type GinRouter struct {
-	routes gin.IRoutes
-	helper *GinHelper
+	routes   gin.IRoutes
+	helper   *GinHelper
+	registry *ginRegistry
+}
+
+// GinRoute describes a route registered through GinRouter, the route options can change its exported fields
+type GinRoute struct {
+	Method   string
+	Path     string
+	Handler  string
+	Request  reflect.Type
+	Response reflect.Type
+	// DisableEnvelope makes the default handlers respond without GinHelper.Envelope, e.g. for raw downloads
+	DisableEnvelope bool
+	// Offers overrides GinHelper.Offers for the route, or GinStreamOffers for the streaming route
+	Offers []string
+	// Stream reports whether the handler streams its Response items
+	Stream bool
+	// Status is the status of the success response, defaults to 200, 204 responds without body
+	Status int
+	// Middlewares run before the handler, after the middlewares of the router
+	Middlewares []gin.HandlerFunc
+	// SuccessHandler, ErrorHandler and BindingErrorHandler override the helper's for the route
+	SuccessHandler      func(*gin.Context, any)
+	ErrorHandler        func(*gin.Context, error)
+	BindingErrorHandler func(*gin.Context, error)
+	// Summary, Description, Tags and Deprecated describe the route in the OpenAPI document
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	// Metadata is free-form, e.g. the auth requirement checked in CI through Routes
+	Metadata map[string]any
+	// Bindings are the names of the bindings of the request, filled on registration
+	Bindings []string
+	// Chain is the names of the handlers gin runs for the route, the group middlewares first, filled on registration
+	Chain []string
+}
+
+// ginRegistry collects the routes of a router and its groups
+type ginRegistry struct {
+	mu     sync.RWMutex
+	routes []*GinRoute
+}
+
+func (g *ginRegistry) add(route GinRoute) *GinRoute {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.routes = append(g.routes, &route)
+	return &route
+}
+
+func (g *ginRegistry) list() []GinRoute {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	routes := make([]GinRoute, 0, len(g.routes))
+	for _, route := range g.routes {
+		routes = append(routes, *route)
+	}
+	return routes
+}
+
+// ginAnyMethods is the same method list used by gin.RouterGroup.Any
+var ginAnyMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodHead, http.MethodOptions, http.MethodDelete, http.MethodConnect,
+	http.MethodTrace,
+}
+
+// Group creates a sub router with its own prefix and middlewares
+//   - Notes: the wrapped routes must implement gin.IRouter
+func (r *GinRouter) Group(relativePath string, middlewares ...gin.HandlerFunc) *GinRouter {
+	router, ok := r.routes.(gin.IRouter)
+	if !ok {
+		panic("routes must implement gin.IRouter to create a group")
+	}
+	return &GinRouter{
+		helper:   r.helper,
+		routes:   router.Group(relativePath, middlewares...),
+		registry: r.registry,
+	}
+}
+
+// BasePath returns the prefix of the routes registered through r
+func (r *GinRouter) BasePath() string {
+	if bp, ok := r.routes.(interface{ BasePath() string }); ok {
+		return bp.BasePath()
+	}
+	return "/"
+}
+
+// Use adds middlewares to the wrapped routes
+func (r *GinRouter) Use(middlewares ...gin.HandlerFunc) *GinRouter {
+	r.routes.Use(middlewares...)
+	return r
+}
+
+func (r *GinRouter) GET(path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	return r.Handle(http.MethodGet, path, handler, options...)
+}
+
+func (r *GinRouter) POST(path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	return r.Handle(http.MethodPost, path, handler, options...)
+}
+
+func (r *GinRouter) PUT(path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	return r.Handle(http.MethodPut, path, handler, options...)
 }
 
-func (r *GinRouter) GET(path string, handler any) *GinRouter {
-	return r.Handle(http.MethodGet, path, handler)
+func (r *GinRouter) PATCH(path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	return r.Handle(http.MethodPatch, path, handler, options...)
 }
 
-func (r *GinRouter) POST(path string, handler any) *GinRouter {
-	return r.Handle(http.MethodPost, path, handler)
+func (r *GinRouter) DELETE(path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	return r.Handle(http.MethodDelete, path, handler, options...)
 }
 
-func (r *GinRouter) Handle(method string, path string, handler any) *GinRouter {
+func (r *GinRouter) HEAD(path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	return r.Handle(http.MethodHead, path, handler, options...)
+}
+
+func (r *GinRouter) OPTIONS(path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	return r.Handle(http.MethodOptions, path, handler, options...)
+}
+
+// Any registers the handler for all the methods gin.RouterGroup.Any does
+func (r *GinRouter) Any(path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	return r.Match(ginAnyMethods, path, handler, options...)
+}
+
+// Match registers the handler for the given methods
+func (r *GinRouter) Match(methods []string, path string, handler any, options ...func(*GinRoute)) *GinRouter {
+	for _, method := range methods {
+		r.Handle(method, path, handler, options...)
+	}
+	return r
+}
+
+// Handle registers the handler for method and path
+//   - options configure the route, e.g. func(route *GinRoute) { route.DisableEnvelope = true }
+func (r *GinRouter) Handle(method string, path string, handler any, options ...func(*GinRoute)) *GinRouter {
 	assertHandler(handler)
 	v := reflect.ValueOf(handler)
 	t := v.Type()
 
-	request := func(c *gin.Context) ([]reflect.Value, error) {
-		in := make([]reflect.Value, 0, t.NumIn())
-		in = append(in, reflect.ValueOf(c))
-		if t.NumIn() == 2 {
-			reqV := reflect.New(t.In(1).Elem())
-			reqT := reqV.Elem().Type()
-			// check if request struct has tags
-			hasTags := make(map[string]bool)
-			hasTags["default"] = true
-			for i := 0; i < reqT.NumField(); i++ {
-				for _, b := range r.helper.Bindings {
-					tag := b.Name()
-					if hasTags[tag] {
-						continue
-					}
-					if _, ok := reqT.Field(i).Tag.Lookup(tag); ok {
-						hasTags[tag] = true
-					}
-				}
-			}
-			// call BeforeBind hook
-			if beforeBinding, ok := reqV.Interface().(BeforeBinding); ok {
-				if err := beforeBinding.BeforeBind(c); err != nil {
-					return nil, errors.Wrap(err, "hook BeforeBind failed")
-				}
-			}
-			// bind
-			for _, b := range r.helper.Bindings {
-				if !hasTags[b.Name()] {
-					continue
-				}
-				err := b.Bind(c, reqV.Interface())
-				if err != nil {
-					return nil, errors.Wrapf(err, "bind %s failed", b.Name())
-				}
-			}
-			// call AfterBind hook
-			if afterBinding, ok := reqV.Interface().(AfterBinding); ok {
-				if err := afterBinding.AfterBind(c); err != nil {
-					return nil, errors.Wrap(err, "hook AfterBind failed")
-				}
-			}
-			// call BeforeValidate hook
-			if beforeValidation, ok := reqV.Interface().(BeforeValidation); ok {
-				if err := beforeValidation.BeforeValidate(c); err != nil {
-					return nil, errors.Wrap(err, "hook BeforeValidate failed")
-				}
+	route := GinRoute{
+		Method:  method,
+		Path:    joinPaths(r.BasePath(), path),
+		Handler: nameOfFunction(handler),
+	}
+	args, request := r.helper.arguments(t)
+	var plan *ginRequestPlan
+	if request >= 0 {
+		route.Request = t.In(request).Elem()
+		plan = newGinRequestPlan(r.helper, route.Request)
+		route.Bindings = plan.bindings()
+	}
+	if t.NumOut() == 2 {
+		if item, ok := ginStreamItem(t.Out(0)); ok {
+			route.Response = item
+			route.Stream = true
+		} else {
+			route.Response = t.Out(0).Elem()
+		}
+	}
+	stream := route.Stream
+	for _, opt := range options {
+		opt(&route)
+	}
+
+	return r.handle(path, route, func(c *gin.Context) {
+		// the arguments stay on the stack for the usual handlers
+		var buf [4]reflect.Value
+		in := buf[:0]
+		if len(args) > len(buf) {
+			in = make([]reflect.Value, 0, len(args))
+		}
+		// the providers run before binding, so their errors, e.g. unauthorized, come first
+		for _, arg := range args {
+			if arg.request {
+				in = append(in, reflect.Value{})
+				continue
 			}
-			// validate
-			err := r.helper.BindingValidator.ValidateStruct(reqV.Elem().Interface())
+			argV, err := arg.provider(c)
 			if err != nil {
-				return nil, errors.Wrap(err, "validate failed")
-			}
-			// call AfterValidate hook
-			if afterValidation, ok := reqV.Interface().(AfterValidation); ok {
-				if err := afterValidation.AfterValidate(c); err != nil {
-					return nil, errors.Wrap(err, "hook AfterValidate failed")
-				}
+				r.onError(c, &route, err)
+				return
 			}
-			in = append(in, reqV)
+			in = append(in, argV)
 		}
-		return in, nil
-	}
-
-	r.routes.Handle(method, path, func(c *gin.Context) {
-		in, err := request(c)
-		if err != nil {
-			r.helper.BindingErrorHandler(c, err)
-			return
+		if plan != nil {
+			reqV := reflect.New(plan.typ)
+			if err := plan.bind(c, reqV.Interface()); err != nil {
+				r.onBindingError(c, &route, err)
+				return
+			}
+			in[request] = reqV
 		}
+		var err error
 		out := v.Call(in)
 		var resp any
 		switch len(out) {
 		case 0:
+			r.onSuccess(c, &route, nil)
 			return
 		case 1:
-			if errVal := out[0].Interface(); errVal != nil {
-				err = errVal.(error)
-			}
+			err = ginError(out[0])
 		case 2:
 			resp = out[0].Interface()
-			if errVal := out[1].Interface(); errVal != nil {
-				err = errVal.(error)
-			}
+			err = ginError(out[1])
 		default:
 			panic("invalid count for handler return values")
 		}
 		if err != nil {
-			r.helper.ErrorHandler(c, err)
+			r.onError(c, &route, err)
 			return
 		}
-		if resp != nil {
-			r.helper.SuccessHandler(c, resp)
+		if stream {
+			if !out[0].IsNil() {
+				r.helper.stream(c, out[0])
+			}
 			return
 		}
+		r.onSuccess(c, &route, resp)
 	})
-
-	return r
-}
-
-type BeforeBinding interface {
-	BeforeBind(c *gin.Context) error
 }
 
-type AfterBinding interface {
-	AfterBind(c *gin.Context) error
-}
-
-type BeforeValidation interface {
-	BeforeValidate(c *gin.Context) error
+// handle records the route and registers the handler after the route middlewares on the wrapped routes
+func (r *GinRouter) handle(path string, route GinRoute, handler gin.HandlerFunc) *GinRouter {
+	for _, h := range ginGroupHandlers(r.routes) {
+		route.Chain = append(route.Chain, nameOfFunction(h))
+	}
+	for _, h := range route.Middlewares {
+		route.Chain = append(route.Chain, nameOfFunction(h))
+	}
+	route.Chain = append(route.Chain, route.Handler)
+	registered := r.registry.add(route)
+	handlers := make([]gin.HandlerFunc, 0, len(route.Middlewares)+2)
+	handlers = append(handlers, func(c *gin.Context) {
+		c.Set(ginRouteKey, registered)
+	})
+	handlers = append(handlers, route.Middlewares...)
+	handlers = append(handlers, func(c *gin.Context) {
+		defer r.recover(c, registered)
+		handler(c)
+	})
+	r.routes.Handle(route.Method, path, handlers...)
+	return r
 }
 
-type AfterValidation interface {
-	AfterValidate(c *gin.Context) error
+// recover converts the panic of the handler or its hooks into an error with the panic value and stack,
+// logs it and calls the ErrorHandler of route
+func (r *GinRouter) recover(c *gin.Context, route *GinRoute) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	if p == http.ErrAbortHandler {
+		panic(p)
+	}
+	var err error
+	if perr, ok := p.(error); ok {
+		err = errors.Wrap(perr, "handler panicked")
+	} else {
+		err = errors.Newf("handler panicked: %v", p)
+	}
+	// the stack is marshalled by the zerolog.ErrorStackMarshaler set by the ZerologHelper, or the default one
+	marshal := zerolog.ErrorStackMarshaler
+	if marshal == nil {
+		marshal = marshalErrorStack
+	}
+	r.helper.logger(c).Error().
+		Err(err).
+		Interface(zerolog.ErrorStackFieldName, marshal(err)).
+		Str("route", route.Path).
+		Msg("recovered from panic")
+	// the response has started, e.g. the stream, so the error can not be written
+	if c.Writer.Written() {
+		c.Abort()
+		return
+	}
+	r.onError(c, route, err)
 }
 
-type GinValidator struct {
-	Validate           *validator.Validate
-	Translator         locales.Translator
-	TranslatorRegister func(v *validator.Validate, trans ut.Translator) error
-	Verbose            bool
-	utTranslator       *ut.UniversalTranslator
+// onSuccess calls the BeforeRender hook of resp, then the SuccessHandler of route, or the helper's.
+// A nil resp has no body, but the route Status is still written.
+func (r *GinRouter) onSuccess(c *gin.Context, route *GinRoute, resp any) {
+	if resp == nil {
+		if route.Status != 0 {
+			c.Status(route.Status)
+		}
+		return
+	}
+	// call BeforeRender hook
+	if hook, ok := resp.(BeforeRendering); ok {
+		if err := hook.BeforeRender(c); err != nil {
+			r.onError(c, route, errors.Wrap(err, "hook BeforeRender failed"))
+			return
+		}
+		// the hook has responded, e.g. redirected
+		if c.Writer.Written() || c.IsAborted() {
+			return
+		}
+	}
+	if route.SuccessHandler != nil {
+		route.SuccessHandler(c, resp)
+		return
+	}
+	r.helper.SuccessHandler(c, resp)
 }
 
-var _ binding.StructValidator = (*GinValidator)(nil)
-
-func NewGinValidator(options ...func(*GinValidator)) *GinValidator {
-	v := validator.New()
-	v.SetTagName("binding")
-
-	gv := &GinValidator{
-		Validate:           v,
-		Translator:         zh.New(),
-		TranslatorRegister: zhTranslations.RegisterDefaultTranslations,
-		Verbose:            false,
+// onError records err in c.Errors, runs the ErrorInterceptors and calls the ErrorHandler of route, or the helper's
+func (r *GinRouter) onError(c *gin.Context, route *GinRoute, err error) {
+	_ = c.Error(err)
+	for _, intercept := range r.helper.ErrorInterceptors {
+		if err = intercept(c, err); err == nil {
+			return
+		}
 	}
-
-	for _, opt := range options {
-		opt(gv)
+	if route.ErrorHandler != nil {
+		route.ErrorHandler(c, err)
+		return
 	}
+	r.helper.ErrorHandler(c, err)
+}
 
-	gv.utTranslator = ut.New(gv.Translator)
-
-	err := gv.TranslatorRegister(v, gv.utTranslator.GetFallback())
-	if err != nil {
-		panic(err)
+// onBindingError records err in c.Errors and calls the BindingErrorHandler of route, or the helper's
+func (r *GinRouter) onBindingError(c *gin.Context, route *GinRoute, err error) {
+	_ = c.Error(err)
+	if route.BindingErrorHandler != nil {
+		route.BindingErrorHandler(c, err)
+		return
 	}
-
-	return gv
+	r.helper.BindingErrorHandler(c, err)
 }
 
-func (v *GinValidator) ValidateStruct(obj any) error {
-	val := reflect.ValueOf(obj)
-	typ := val.Type()
+const ginRouteKey = "github.com/fioepq9/helper/route"
 
-	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem()
+// GinRouteFromContext returns the route which handles c, if it is registered through GinRouter
+func GinRouteFromContext(c *gin.Context) (*GinRoute, bool) {
+	v, ok := c.Get(ginRouteKey)
+	if !ok {
+		return nil, false
 	}
+	route, ok := v.(*GinRoute)
+	return route, ok
+}
 
-	if typ.Kind() != reflect.Struct {
-		return nil
-	}
+type BeforeBinding interface {
+	BeforeBind(c *gin.Context) error
+}
 
-	err := v.Validate.Struct(obj)
-	if err != nil {
-		errs := err.(validator.ValidationErrors)
-		if v.Verbose {
-			kvTuple := make([]string, 0, len(errs))
-			for k, v := range errs.Translate(v.utTranslator.GetFallback()) {
-				kvTuple = append(kvTuple, k+"="+v)
-			}
-			return errors.Newf("[%s]", strings.Join(kvTuple, ","))
-		}
-		var buf bytes.Buffer
-		buf.WriteByte('[')
-		vSlice := make([]string, 0, len(errs))
-		for _, v := range errs.Translate(v.utTranslator.GetFallback()) {
-			vSlice = append(vSlice, v)
-		}
-		buf.WriteString(strings.Join(vSlice, ","))
-		buf.WriteByte(']')
-		return errors.New(buf.String())
-	}
+type AfterBinding interface {
+	AfterBind(c *gin.Context) error
+}
 
-	return nil
+type BeforeValidation interface {
+	BeforeValidate(c *gin.Context) error
+}
+
+type AfterValidation interface {
+	AfterValidate(c *gin.Context) error
 }
 
-func (v *GinValidator) Engine() any {
-	return v.Validate
+// BeforeRendering is implemented by the response types, BeforeRender runs before the SuccessHandler.
+// It can mask the fields, set the headers or the status by c.Status, its error goes to the ErrorHandler.
+type BeforeRendering interface {
+	BeforeRender(c *gin.Context) error
 }
 
 // assertHandler checks if handler is valid
 // handler must be a function
-// handler's first argument must be *gin.Context
-// handler's second argument must be a struct
+// handler's arguments must be provided by GinHelper.Providers, except one struct pointer as the request
 // handler's last return value must be error
 // handler's first return value must be a pointer
 // example:
@@ -300,6 +574,7 @@ func (v *GinValidator) Engine() any {
 //   - func(c *gin.Context, *req) error
 //   - func(c *gin.Context) (*resp, error)
 //   - func(c *gin.Context, *req) (*resp, error)
+//   - func(ctx context.Context, log *zerolog.Logger, *req) (*resp, error)
 func assertHandler(handler any) {
 	v := reflect.ValueOf(handler)
 	t := v.Type()
@@ -308,17 +583,6 @@ func assertHandler(handler any) {
 		panic("handler must be a function")
 	}
 
-	if t.NumIn() == 0 || t.NumIn() > 2 {
-		panic("handler must have 1 or 2 arguments")
-	}
-	if t.In(0) != reflect.TypeOf(&gin.Context{}) {
-		panic("handler's first argument must be *gin.Context")
-	}
-	if t.NumIn() == 2 &&
-		(t.In(1).Kind() != reflect.Ptr || t.In(1).Elem().Kind() != reflect.Struct) {
-		panic("handler's second argument must be a struct pointer")
-	}
-
 	if t.NumOut() > 2 {
 		panic("handler return values count must be 2 or less")
 	}
@@ -326,6 +590,37 @@ func assertHandler(handler any) {
 		panic("handler's last return value must be error")
 	}
 	if t.NumOut() == 2 && t.Out(0).Kind() != reflect.Ptr {
-		panic("handler's first return value must be a pointer")
+		if _, ok := ginStreamItem(t.Out(0)); !ok {
+			panic("handler's first return value must be a pointer, a channel or an iterator")
+		}
 	}
 }
+
+// ginError returns the error returned by the handler,
+// the nil pointer of a concrete error type is no error rather than a non-nil error
+func ginError(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	err, _ := v.Interface().(error)
+	return err
+}
+
+// joinPaths joins the paths the same way gin.RouterGroup does
+func joinPaths(absolutePath, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+	finalPath := path.Join(absolutePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+		return finalPath + "/"
+	}
+	return finalPath
+}
+
+func nameOfFunction(f any) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
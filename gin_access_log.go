@@ -0,0 +1,101 @@
+package helper
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// GinAccessLog logs each request with method, route, path, status, latency, bytes_in, bytes_out,
+// client_ip, user_agent, request_id and the error of the handler
+type GinAccessLog struct {
+	// Logger writes the access logs, nil to use the request logger
+	Logger *zerolog.Logger
+	// Levels are the levels by status class, e.g. 5 for 5xx, the missing classes use zerolog.InfoLevel
+	Levels map[int]zerolog.Level
+	// Sampler samples the access logs by level, nil to log all, e.g. zerolog.LevelSampler{InfoSampler: &zerolog.BasicSampler{N: 10}}
+	Sampler zerolog.Sampler
+	// SkipPaths are the paths or route templates not logged, e.g. /healthz
+	SkipPaths []string
+	// Redactor masks the sensitive path parameters, e.g. /reset/:token, nil to log the paths as is
+	Redactor *Redactor
+}
+
+func NewGinAccessLog(options ...func(*GinAccessLog)) *GinAccessLog {
+	l := &GinAccessLog{
+		Levels: map[int]zerolog.Level{
+			4: zerolog.WarnLevel,
+			5: zerolog.ErrorLevel,
+		},
+		Redactor: NewRedactor(),
+	}
+
+	for _, opt := range options {
+		opt(l)
+	}
+
+	return l
+}
+
+// AccessLog returns the access log middleware.
+// The fields request_id, method, route and client_ip are not repeated when the RequestLogger runs.
+func (h *GinHelper) AccessLog(options ...func(*GinAccessLog)) gin.HandlerFunc {
+	l := NewGinAccessLog(options...)
+	skip := make(map[string]struct{}, len(l.SkipPaths))
+	for _, p := range l.SkipPaths {
+		skip[p] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		if _, ok := skip[c.Request.URL.Path]; ok {
+			c.Next()
+			return
+		}
+		if _, ok := skip[c.FullPath()]; ok {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		status := c.Writer.Status()
+		level, ok := l.Levels[status/100]
+		if !ok {
+			level = zerolog.InfoLevel
+		}
+		if l.Sampler != nil && !l.Sampler.Sample(level) {
+			return
+		}
+		logger := l.Logger
+		if logger == nil {
+			logger = h.logger(c)
+		}
+
+		evt := logger.WithLevel(level)
+		// the request logger of GinLoggerKey has the correlation fields already
+		if v, ok := c.Get(GinLoggerKey); !ok || v != logger {
+			evt = evt.
+				Str("request_id", GinRequestID(c)).
+				Str("method", c.Request.Method).
+				Str("route", c.FullPath()).
+				Str("client_ip", c.ClientIP())
+		}
+		path := c.Request.URL.Path
+		if l.Redactor != nil {
+			path = l.Redactor.RedactPath(c.FullPath(), path)
+		}
+		evt = evt.
+			Str("path", path).
+			Int("status", status).
+			Dur("latency", latency).
+			Int64("bytes_in", max(c.Request.ContentLength, 0)).
+			Int("bytes_out", max(c.Writer.Size(), 0)).
+			Str("user_agent", c.Request.UserAgent())
+		if err := c.Errors.Last(); err != nil {
+			evt = evt.Err(err.Err)
+		}
+		evt.Msg("access")
+	}
+}
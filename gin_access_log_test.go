@@ -0,0 +1,155 @@
+package helper_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Access Log", Label("gin", "access"), func() {
+	type AccessRequest struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	var (
+		k   *helpertest.Kit
+		h   *helper.GinHelper
+		buf *bytes.Buffer
+	)
+
+	lines := func() []map[string]any {
+		var res []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			m := make(map[string]any)
+			Expect(json.Unmarshal([]byte(line), &m)).To(Succeed())
+			res = append(res, m)
+		}
+		return res
+	}
+
+	client := []func(*http.Request){
+		helpertest.WithHeader("User-Agent", "ginkgo"),
+		helpertest.WithHeader(helper.GinRequestIDHeader, "req-1"),
+	}
+
+	register := func(middlewares ...gin.HandlerFunc) {
+		k.Engine.Use(middlewares...)
+		r := k.Router
+		r.POST("/items", func(c *gin.Context, req *AccessRequest) error {
+			return nil
+		})
+		r.GET("/items/:id", func(c *gin.Context) error {
+			return helper.NewHTTPError(http.StatusNotFound, "not found")
+		})
+		r.GET("/panic", func(c *gin.Context) error {
+			panic("boom")
+		})
+		r.GET("/healthz", func(c *gin.Context) error {
+			return nil
+		})
+	}
+
+	BeforeEach(func() {
+		buf = new(bytes.Buffer)
+		k = helpertest.New(func(h *helper.GinHelper) {
+			log := zerolog.New(buf)
+			h.Logger = &log
+		})
+		h = k.Helper
+	})
+
+	It("should log the request fields with the level of the status class", func() {
+		register(h.AccessLog())
+
+		k.Do(http.MethodPost, "/items", &AccessRequest{Name: "book"}, client...)
+		k.Do(http.MethodGet, "/items/1", nil, client...)
+		k.Do(http.MethodPost, "/items", &AccessRequest{}, client...)
+		logs := lines()
+		Expect(logs).To(HaveLen(3))
+
+		Expect(logs[0]).To(HaveKeyWithValue("level", "info"))
+		Expect(logs[0]).To(HaveKeyWithValue("message", "access"))
+		Expect(logs[0]).To(HaveKeyWithValue("request_id", "req-1"))
+		Expect(logs[0]).To(HaveKeyWithValue("method", "POST"))
+		Expect(logs[0]).To(HaveKeyWithValue("route", "/items"))
+		Expect(logs[0]).To(HaveKeyWithValue("path", "/items"))
+		Expect(logs[0]).To(HaveKeyWithValue("status", BeNumerically("==", http.StatusOK)))
+		Expect(logs[0]).To(HaveKeyWithValue("bytes_in", BeNumerically("==", len(`{"name":"book"}`))))
+		Expect(logs[0]).To(HaveKeyWithValue("user_agent", "ginkgo"))
+		Expect(logs[0]).To(HaveKey("latency"))
+		Expect(logs[0]).To(HaveKey("client_ip"))
+		Expect(logs[0]).NotTo(HaveKey("error"))
+
+		Expect(logs[1]).To(HaveKeyWithValue("level", "warn"))
+		Expect(logs[1]).To(HaveKeyWithValue("route", "/items/:id"))
+		Expect(logs[1]).To(HaveKeyWithValue("path", "/items/1"))
+		Expect(logs[1]).To(HaveKeyWithValue("error", "not found"))
+		Expect(logs[1]["bytes_out"]).To(BeNumerically(">", 0))
+
+		Expect(logs[2]).To(HaveKeyWithValue("level", "warn"))
+		Expect(logs[2]).To(HaveKey("error"))
+	})
+
+	It("should log the recovered panics as errors", func() {
+		register(h.AccessLog())
+
+		k.Do(http.MethodGet, "/panic", nil, client...)
+		logs := lines()
+		access := logs[len(logs)-1]
+		Expect(access).To(HaveKeyWithValue("level", "error"))
+		Expect(access).To(HaveKeyWithValue("status", BeNumerically("==", http.StatusInternalServerError)))
+		Expect(access["error"]).To(ContainSubstring("boom"))
+	})
+
+	It("should skip the paths and sample the logs", func() {
+		register(h.AccessLog(func(l *helper.GinAccessLog) {
+			l.SkipPaths = []string{"/healthz", "/items/:id"}
+			l.Sampler = zerolog.LevelSampler{InfoSampler: &zerolog.BasicSampler{N: 2}}
+		}))
+
+		k.Do(http.MethodGet, "/healthz", nil, client...)
+		k.Do(http.MethodGet, "/items/1", nil, client...)
+		Expect(buf.String()).To(BeEmpty())
+
+		for i := 0; i < 4; i++ {
+			k.Do(http.MethodPost, "/items", &AccessRequest{Name: "book"}, client...)
+		}
+		Expect(lines()).To(HaveLen(2))
+	})
+
+	It("should not repeat the fields of the request logger", func() {
+		register(h.RequestLogger(), h.AccessLog())
+
+		k.Do(http.MethodPost, "/items", &AccessRequest{Name: "book"}, client...)
+		line := strings.TrimSpace(buf.String())
+		Expect(strings.Count(line, `"request_id"`)).To(Equal(1))
+		Expect(strings.Count(line, `"route"`)).To(Equal(1))
+		Expect(lines()[0]).To(HaveKeyWithValue("request_id", "req-1"))
+	})
+
+	It("should log the fields of the request with a custom logger", func() {
+		var custom bytes.Buffer
+		log := zerolog.New(&custom)
+		register(h.RequestLogger(), h.AccessLog(func(l *helper.GinAccessLog) {
+			l.Logger = &log
+		}))
+
+		k.Do(http.MethodPost, "/items", &AccessRequest{Name: "book"}, client...)
+		m := make(map[string]any)
+		Expect(json.Unmarshal(custom.Bytes(), &m)).To(Succeed())
+		Expect(m).To(HaveKeyWithValue("request_id", "req-1"))
+		Expect(m).To(HaveKeyWithValue("route", "/items"))
+	})
+})
@@ -1,12 +1,20 @@
 package helper
 
 import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/mitchellh/mapstructure"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/ugorji/go/codec"
+	yaml "gopkg.in/yaml.v3"
 )
 
 type GinBinding interface {
@@ -14,26 +22,50 @@ type GinBinding interface {
 	Bind(c *gin.Context, obj any) error
 }
 
+// GinBindFunc binds the request of c into obj
+type GinBindFunc func(c *gin.Context, obj any) error
+
+// GinBodyBinding is implemented by bindings which read the request body.
+// They only run when the Content-Type of the request is one of MIMETypes,
+// so a request type can have several body tags, e.g. json and form.
+type GinBodyBinding interface {
+	GinBinding
+	MIMETypes() []string
+}
+
+// GinPreparedBinding is implemented by bindings which can prepare their work
+// for a request type once, when the route is registered.
+type GinPreparedBinding interface {
+	GinBinding
+	// Prepare returns the bind function of typ, or nil if there is nothing to bind.
+	Prepare(typ reflect.Type) (GinBindFunc, error)
+}
+
 type GinDefaultBinding struct {
 	TagName     string
 	DecodeHooks []mapstructure.DecodeHookFunc
 }
 
+// defaultGinDecodeHooks are the decode hooks which convert the strings of the default values and cookies
+func defaultGinDecodeHooks() []mapstructure.DecodeHookFunc {
+	return []mapstructure.DecodeHookFunc{
+		StringToSliceHookFunc(","),
+		StringToBoolHookFunc(),
+		StringToIntHookFunc(),
+		StringToFloat64HookFunc(),
+		StringToBytesHookFunc(),
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.OrComposeDecodeHookFunc(
+			StringToTimeHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+			mapstructure.StringToTimeHookFunc(time.RFC3339Nano),
+		),
+	}
+}
+
 func NewGinDefaultBinding(options ...func(*GinDefaultBinding)) *GinDefaultBinding {
 	b := &GinDefaultBinding{
-		DecodeHooks: []mapstructure.DecodeHookFunc{
-			StringToSliceHookFunc(","),
-			StringToBoolHookFunc(),
-			StringToIntHookFunc(),
-			StringToFloat64HookFunc(),
-			StringToBytesHookFunc(),
-			mapstructure.StringToTimeDurationHookFunc(),
-			mapstructure.OrComposeDecodeHookFunc(
-				StringToTimeHookFunc(),
-				mapstructure.StringToTimeHookFunc(time.RFC3339),
-				mapstructure.StringToTimeHookFunc(time.RFC3339Nano),
-			),
-		},
+		DecodeHooks: defaultGinDecodeHooks(),
 	}
 
 	for _, opt := range options {
@@ -48,57 +80,169 @@ func (b *GinDefaultBinding) Name() string {
 }
 
 func (b *GinDefaultBinding) Bind(c *gin.Context, obj any) error {
-	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+	bind, err := b.Prepare(reflect.TypeOf(obj))
+	if err != nil || bind == nil {
+		return err
+	}
+	return bind(c, obj)
+}
+
+func (b *GinDefaultBinding) Prepare(typ reflect.Type) (GinBindFunc, error) {
+	fields := ginTaggedFields(typ, b.Name())
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	// the fields and config are only read, so they are shared by all requests
+	config := mapstructure.DecoderConfig{
 		TagName:    b.TagName,
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(b.DecodeHooks...),
-		Result:     obj,
-	})
-	if err != nil {
-		return err
+		Squash:     true,
+	}
+	return func(c *gin.Context, obj any) error {
+		v := reflect.ValueOf(obj).Elem()
+		for _, f := range fields {
+			if err := decodeGinField(config, ginFieldByIndex(v, f.index), f.value); err != nil {
+				return errors.Wrapf(err, "decode %s", f.name)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// ginTaggedField is a field with tag of a struct or of its embedded structs
+type ginTaggedField struct {
+	name  string
+	index []int
+	value string
+}
+
+// ginTaggedFields returns the exported fields of typ and its embedded structs which have tag
+func ginTaggedFields(typ reflect.Type, tag string) []ginTaggedField {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields []ginTaggedField
+	for _, f := range reflect.VisibleFields(typ) {
+		value, ok := f.Tag.Lookup(tag)
+		if !ok || !f.IsExported() || f.Anonymous {
+			continue
+		}
+		fields = append(fields, ginTaggedField{name: f.Name, index: f.Index, value: value})
+	}
+	return fields
+}
+
+// ginFieldByIndex returns the field of the struct v by index, allocating the nil embedded pointers
+func ginFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
 	}
-	t := reflect.TypeOf(obj)
+	return v
+}
 
-	var parseStruct func(t reflect.Type) map[string]any
-	parseStruct = func(t reflect.Type) map[string]any {
-		if t.Kind() == reflect.Ptr {
-			t = t.Elem()
+// decodeGinField decodes the string value into the field v, the values converted by the decode hook
+// to the type of v are set directly, the others are decoded by mapstructure
+func decodeGinField(config mapstructure.DecoderConfig, v reflect.Value, value string) error {
+	if config.DecodeHook != nil {
+		data, err := mapstructure.DecodeHookExec(config.DecodeHook, reflect.ValueOf(value), v)
+		if err != nil {
+			return err
 		}
-		if t.Kind() != reflect.Struct {
+		if dv := reflect.ValueOf(data); dv.IsValid() && dv.Type().AssignableTo(v.Type()) {
+			v.Set(dv)
 			return nil
 		}
-		dict := make(map[string]any)
-		for i := 0; i < t.NumField(); i++ {
-			f := t.Field(i)
-			name := f.Name
-			if tName, ok := f.Tag.Lookup(b.TagName); ok {
-				name = tName
-			}
-			if f.Anonymous {
-				for k, v := range parseStruct(f.Type) {
-					dict[k] = v
-				}
-			}
-			if defaultStr, ok := f.Tag.Lookup(b.Name()); ok {
-				dict[name] = defaultStr
-			}
+	}
+	config.Result = v.Addr().Interface()
+	decoder, err := mapstructure.NewDecoder(&config)
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(value)
+}
+
+// GinCookieBinding binds the cookies into the fields with tag cookie, e.g. `cookie:"session_id"`.
+// The values are converted by the same decode hooks as GinDefaultBinding,
+// the absent cookies keep the default values.
+type GinCookieBinding struct {
+	TagName     string
+	DecodeHooks []mapstructure.DecodeHookFunc
+}
+
+func NewGinCookieBinding(options ...func(*GinCookieBinding)) *GinCookieBinding {
+	b := &GinCookieBinding{
+		TagName:     "cookie",
+		DecodeHooks: defaultGinDecodeHooks(),
+	}
+
+	for _, opt := range options {
+		opt(b)
+	}
+
+	return b
+}
+
+func (b *GinCookieBinding) Name() string {
+	return b.TagName
+}
+
+func (b *GinCookieBinding) Bind(c *gin.Context, obj any) error {
+	bind, err := b.Prepare(reflect.TypeOf(obj))
+	if err != nil || bind == nil {
+		return err
+	}
+	return bind(c, obj)
+}
+
+func (b *GinCookieBinding) Prepare(typ reflect.Type) (GinBindFunc, error) {
+	var fields []ginTaggedField
+	for _, f := range ginTaggedFields(typ, b.TagName) {
+		if name, _, _ := strings.Cut(f.value, ","); name != "" && name != "-" {
+			f.value = name
+			fields = append(fields, f)
 		}
-		return dict
 	}
-	dict := parseStruct(t)
-	if len(dict) == 0 {
-		return nil
+	if len(fields) == 0 {
+		return nil, nil
 	}
 
-	return decoder.Decode(dict)
+	// only the fields with the tag are assigned, by index, so the other fields keep their values
+	config := mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(b.DecodeHooks...),
+	}
+	return func(c *gin.Context, obj any) error {
+		v := reflect.ValueOf(obj).Elem()
+		for _, f := range fields {
+			value, err := c.Cookie(f.value)
+			if err != nil {
+				continue
+			}
+			if err := decodeGinField(config, ginFieldByIndex(v, f.index), value); err != nil {
+				return errors.Wrapf(err, "decode %s", f.value)
+			}
+		}
+		return nil
+	}, nil
 }
 
 type GinURIBinding struct {
-	Params     func(*gin.Context) map[string][]string
+	Params func(*gin.Context) map[string][]string
+	// BindingURI binds the params, nil to map the uri tags without validation
 	BindingURI binding.BindingUri
 }
 
-func NewGinURIBinding() *GinURIBinding {
-	return &GinURIBinding{
+func NewGinURIBinding(options ...func(*GinURIBinding)) *GinURIBinding {
+	b := &GinURIBinding{
 		Params: func(c *gin.Context) map[string][]string {
 			m := make(map[string][]string)
 			for _, v := range c.Params {
@@ -108,6 +252,12 @@ func NewGinURIBinding() *GinURIBinding {
 		},
 		BindingURI: binding.Uri,
 	}
+
+	for _, opt := range options {
+		opt(b)
+	}
+
+	return b
 }
 
 func (b *GinURIBinding) Name() string {
@@ -116,6 +266,9 @@ func (b *GinURIBinding) Name() string {
 
 func (b *GinURIBinding) Bind(c *gin.Context, obj any) error {
 	m := b.Params(c)
+	if b.BindingURI == nil {
+		return binding.MapFormWithTag(obj, m, "uri")
+	}
 	return b.BindingURI.BindUri(m, obj)
 }
 
@@ -136,3 +289,159 @@ func (b *GinBindingWrapper) Name() string {
 func (b *GinBindingWrapper) Bind(c *gin.Context, obj any) error {
 	return b.Binding.Bind(c.Request, obj)
 }
+
+// MIMETypes returns the Content-Types read by the wrapped binding, nil if it does not read the body
+func (b *GinBindingWrapper) MIMETypes() []string {
+	return ginBodyMIMETypes[b.Name()]
+}
+
+// GinDecodeBinding decodes the request like the wrapped gin binding but without its validation,
+// the request is validated once by GinHelper.BindingValidator after all the bindings.
+// The bindings unknown to it are called as they are, see GinBindingWrapper.
+type GinDecodeBinding struct {
+	Binding binding.Binding
+}
+
+func NewGinDecodeBinding(b binding.Binding) *GinDecodeBinding {
+	return &GinDecodeBinding{
+		Binding: b,
+	}
+}
+
+func (b *GinDecodeBinding) Name() string {
+	return b.Binding.Name()
+}
+
+func (b *GinDecodeBinding) Bind(c *gin.Context, obj any) error {
+	req := c.Request
+	switch b.Name() {
+	case "json":
+		decoder := json.NewDecoder(req.Body)
+		if binding.EnableDecoderUseNumber {
+			decoder.UseNumber()
+		}
+		if binding.EnableDecoderDisallowUnknownFields {
+			decoder.DisallowUnknownFields()
+		}
+		return decoder.Decode(obj)
+	case "xml":
+		return xml.NewDecoder(req.Body).Decode(obj)
+	case "yaml":
+		return yaml.NewDecoder(req.Body).Decode(obj)
+	case "toml":
+		return toml.NewDecoder(req.Body).Decode(obj)
+	case "msgpack":
+		return codec.NewDecoder(req.Body, new(codec.MsgpackHandle)).Decode(obj)
+	case "query":
+		return binding.MapFormWithTag(obj, req.URL.Query(), "form")
+	case "form":
+		if c.ContentType() == binding.MIMEMultipartPOSTForm {
+			if _, err := c.MultipartForm(); err != nil {
+				return err
+			}
+		} else if err := req.ParseForm(); err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(obj, req.Form, "form")
+	case "form-urlencoded":
+		if err := req.ParseForm(); err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(obj, req.PostForm, "form")
+	case "multipart/form-data":
+		form, err := c.MultipartForm()
+		if err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(obj, form.Value, "form")
+	case "header":
+		return bindGinHeader(req.Header, ginTagNames(reflect.TypeOf(obj), "header"), obj)
+	default:
+		return b.Binding.Bind(req, obj)
+	}
+}
+
+// MIMETypes returns the Content-Types read by the wrapped binding, nil if it does not read the body
+func (b *GinDecodeBinding) MIMETypes() []string {
+	return ginBodyMIMETypes[b.Name()]
+}
+
+// Prepare binds typ if it has the tag read by the wrapped binding, e.g. form for binding.Query
+func (b *GinDecodeBinding) Prepare(typ reflect.Type) (GinBindFunc, error) {
+	tags, ok := ginBindingTags[b.Name()]
+	if !ok {
+		tags = []string{b.Name()}
+	}
+	for _, tag := range tags {
+		if !ginHasTag(typ, tag) {
+			continue
+		}
+		if b.Name() == "header" {
+			names := ginTagNames(typ, "header")
+			return func(c *gin.Context, obj any) error {
+				return bindGinHeader(c.Request.Header, names, obj)
+			}, nil
+		}
+		return b.Bind, nil
+	}
+	return nil, nil
+}
+
+// bindGinHeader maps the headers of names into obj, the header names are case-insensitive
+func bindGinHeader(header http.Header, names []string, obj any) error {
+	values := make(map[string][]string, len(names))
+	for _, name := range names {
+		if v := header.Values(name); len(v) > 0 {
+			values[name] = v
+		}
+	}
+	return binding.MapFormWithTag(obj, values, "header")
+}
+
+// ginTagNames returns the names of the fields of typ and its embedded structs which have tag
+func ginTagNames(typ reflect.Type, tag string) []string {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for _, f := range reflect.VisibleFields(typ) {
+		value, ok := f.Tag.Lookup(tag)
+		if !ok || f.Anonymous {
+			continue
+		}
+		if name, _, _ := strings.Cut(value, ","); name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ginBindingTags are the tags read by the gin bindings whose tag is not their name
+var ginBindingTags = map[string][]string{
+	"query":               {"form"},
+	"form-urlencoded":     {"form"},
+	"multipart/form-data": {"form"},
+	"msgpack":             {"codec", "json"},
+}
+
+// ginQueryBodyBindings are the body bindings which also read the query,
+// the request is not rejected when its body has another Content-Type
+var ginQueryBodyBindings = map[string]bool{
+	"form": true,
+}
+
+// ginBodyMIMETypes are the Content-Types read by the gin body bindings
+var ginBodyMIMETypes = map[string][]string{
+	"json":                {binding.MIMEJSON},
+	"xml":                 {binding.MIMEXML, binding.MIMEXML2},
+	"yaml":                {binding.MIMEYAML},
+	"toml":                {binding.MIMETOML},
+	"msgpack":             {binding.MIMEMSGPACK, binding.MIMEMSGPACK2},
+	"protobuf":            {binding.MIMEPROTOBUF},
+	"form":                {binding.MIMEPOSTForm, binding.MIMEMultipartPOSTForm},
+	"form-urlencoded":     {binding.MIMEPOSTForm},
+	"multipart/form-data": {binding.MIMEMultipartPOSTForm},
+}
@@ -0,0 +1,110 @@
+package helper_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper/helpertest"
+)
+
+type bodyCommentRequest struct {
+	Post    int    `uri:"post"`
+	Author  string `json:"author" form:"author" xml:"author" yaml:"author" toml:"author" binding:"required"`
+	Content string `json:"content" form:"content" xml:"content" yaml:"content" toml:"content"`
+	raw     string
+}
+
+// AfterBind reads the body again, which is cached by the plan
+func (r *bodyCommentRequest) AfterBind(c *gin.Context) error {
+	raw, err := io.ReadAll(c.Request.Body)
+	r.raw = string(raw)
+	return err
+}
+
+var _ = Describe("Checking Body Binding", Label("gin", "body"), func() {
+	type CommentResponse struct {
+		Post    int    `json:"post"`
+		Author  string `json:"author"`
+		Content string `json:"content"`
+		Raw     string `json:"raw"`
+	}
+
+	var k *helpertest.Kit
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		k.Router.POST("/posts/:post/comments", func(c *gin.Context, req *bodyCommentRequest) (*CommentResponse, error) {
+			return &CommentResponse{Post: req.Post, Author: req.Author, Content: req.Content, Raw: req.raw}, nil
+		})
+	})
+
+	DescribeTable("should bind the body chosen by Content-Type",
+		func(contentType, body string) {
+			w := k.Do(http.MethodPost, "/posts/1/comments", nil, helpertest.WithBody(contentType, strings.NewReader(body)))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			raw, err := json.Marshal(body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Body.String()).To(MatchJSON(`{"post":1,"author":"alice","content":"hi","raw":` + string(raw) + `}`))
+		},
+		Entry("json", binding.MIMEJSON+"; charset=utf-8", `{"author":"alice","content":"hi"}`),
+		Entry("form", binding.MIMEPOSTForm, `author=alice&content=hi`),
+		Entry("xml", binding.MIMEXML, `<comment><author>alice</author><content>hi</content></comment>`),
+		Entry("yaml", binding.MIMEYAML, "author: alice\ncontent: hi\n"),
+		Entry("toml", binding.MIMETOML, "author = 'alice'\ncontent = 'hi'\n"),
+	)
+
+	It("should bind the multipart form", func() {
+		body := new(bytes.Buffer)
+		mw := multipart.NewWriter(body)
+		Expect(mw.WriteField("author", "alice")).To(Succeed())
+		Expect(mw.WriteField("content", "hi")).To(Succeed())
+		Expect(mw.Close()).To(Succeed())
+		w := k.Do(http.MethodPost, "/posts/1/comments", nil, helpertest.WithBody(mw.FormDataContentType(), body))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(`"author":"alice","content":"hi"`))
+	})
+
+	It("should respond 415 for the unsupported Content-Type", func() {
+		w := k.Do(http.MethodPost, "/posts/1/comments", nil, helpertest.WithBody("text/csv", strings.NewReader("alice,hi")))
+		Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+		Expect(w.Body.String()).To(MatchJSON(`{"code":415,"message":"Unsupported Media Type"}`))
+	})
+
+	It("should bind the query of the form only request with another Content-Type", func() {
+		type WebhookRequest struct {
+			Sig string `form:"sig"`
+		}
+		type WebhookResponse struct {
+			Sig string `json:"sig"`
+			Raw string `json:"raw"`
+		}
+		k.Router.POST("/webhooks", func(c *gin.Context, req *WebhookRequest) (*WebhookResponse, error) {
+			raw, err := io.ReadAll(c.Request.Body)
+			return &WebhookResponse{Sig: req.Sig, Raw: string(raw)}, err
+		})
+		for _, contentType := range []string{binding.MIMEJSON, binding.MIMEPlain} {
+			w := k.Do(http.MethodPost, "/webhooks?sig=abc", nil, helpertest.WithBody(contentType, strings.NewReader(`{"event":"push"}`)))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(MatchJSON(`{"sig":"abc","raw":"{\"event\":\"push\"}"}`))
+		}
+	})
+
+	It("should bind the body without Content-Type as JSON", func() {
+		w := k.Do(http.MethodPost, "/posts/1/comments", nil, helpertest.WithBody("", strings.NewReader(`{"author":"alice"}`)))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(`"author":"alice"`))
+
+		w = k.Do(http.MethodPost, "/posts/1/comments", nil)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring(`"field":"author","rule":"required"`))
+	})
+})
@@ -0,0 +1,275 @@
+package helper
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/ugorji/go/codec"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// GinClientRequest is the request encoded from the tags of a request struct, the way the bindings decode it
+type GinClientRequest struct {
+	PathParams map[string]string
+	Query      url.Values
+	Header     http.Header
+	Cookies    []*http.Cookie
+	// Body is the encoded body, nil without body fields or for GET and HEAD
+	Body []byte
+	// ContentType is the Content-Type of Body
+	ContentType string
+}
+
+// ginClientBodyBindings are the body bindings the client encodes, in the order they are chosen
+var ginClientBodyBindings = []string{"json", "xml", "yaml", "toml", "msgpack", "form"}
+
+// GinClientBodyBinding returns the binding the client encodes the body for among the bindings of a route,
+// the first of json, xml, yaml, toml, msgpack and form, "" for none
+func GinClientBodyBinding(bindings []string) string {
+	for _, name := range ginClientBodyBindings {
+		for _, b := range bindings {
+			if b == name {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// NewGinClientRequest encodes req for method, req is a struct (pointer) or the map[string]string of the path params, nil for none.
+// bodyBinding is the binding which decodes the body, see GinClientBodyBinding, the body is not sent for GET and HEAD.
+//   - uri: the path params
+//   - form: the query, zero values are omitted, or the urlencoded body for the form binding
+//   - header, cookie: zero values are omitted
+//   - json: the JSON body of the json fields
+//   - xml, yaml, toml, msgpack: the body of the whole req
+func NewGinClientRequest(method string, req any, bodyBinding string) (*GinClientRequest, error) {
+	r := &GinClientRequest{
+		PathParams: make(map[string]string),
+		Query:      make(url.Values),
+		Header:     make(http.Header),
+	}
+	if params, ok := req.(map[string]string); ok {
+		r.PathParams = params
+		return r, nil
+	}
+	if req == nil {
+		return r, nil
+	}
+	v := reflect.Indirect(reflect.ValueOf(req))
+	if v.Kind() != reflect.Struct {
+		return nil, errors.Newf("request %s is not a struct", v.Type())
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		bodyBinding = ""
+	}
+	body := make(map[string]any)
+	form := make(url.Values)
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		if name, ok := ginClientTagName(f, "uri"); ok {
+			r.PathParams[name] = formatGinClientValue(fv)
+		}
+		if name, ok := ginClientTagName(f, "json"); ok {
+			body[name] = fv.Interface()
+		}
+		if fv.IsZero() {
+			continue
+		}
+		// the form fields encoded in the body are not repeated in the query, the query binding would bind them twice
+		if name, ok := ginClientTagName(f, "form"); ok && bodyBinding == "form" {
+			form[name] = append(form[name], formatGinClientValues(fv)...)
+		} else if ok && !ginClientInBody(f, bodyBinding) {
+			r.Query[name] = append(r.Query[name], formatGinClientValues(fv)...)
+		}
+		if name, ok := ginClientTagName(f, "header"); ok {
+			for _, s := range formatGinClientValues(fv) {
+				r.Header.Add(name, s)
+			}
+		}
+		if name, ok := ginClientTagName(f, "cookie"); ok {
+			r.Cookies = append(r.Cookies, &http.Cookie{Name: name, Value: formatGinClientValue(fv)})
+		}
+	}
+	var err error
+	switch bodyBinding {
+	case "":
+	case "json":
+		if len(body) > 0 {
+			r.ContentType = binding.MIMEJSON
+			r.Body, err = json.Marshal(body)
+		}
+	case "form":
+		if len(form) > 0 {
+			r.ContentType = binding.MIMEPOSTForm
+			r.Body = []byte(form.Encode())
+		}
+	case "xml":
+		r.ContentType = binding.MIMEXML
+		r.Body, err = xml.Marshal(req)
+	case "yaml":
+		r.ContentType = binding.MIMEYAML
+		r.Body, err = yaml.Marshal(req)
+	case "toml":
+		r.ContentType = binding.MIMETOML
+		r.Body, err = toml.Marshal(req)
+	case "msgpack":
+		r.ContentType = binding.MIMEMSGPACK
+		err = codec.NewEncoderBytes(&r.Body, new(codec.MsgpackHandle)).Encode(req)
+	default:
+		return nil, errors.Newf("body binding %s is not supported", bodyBinding)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "encode %s body failed", bodyBinding)
+	}
+	return r, nil
+}
+
+// Target replaces the params :name, *name and {name} in path and appends the query
+func (r *GinClientRequest) Target(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		switch {
+		case strings.HasPrefix(s, ":"):
+			segments[i] = url.PathEscape(r.PathParams[s[1:]])
+		case strings.HasPrefix(s, "*"):
+			segments[i] = strings.TrimPrefix(r.PathParams[s[1:]], "/")
+		case strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}"):
+			segments[i] = url.PathEscape(r.PathParams[s[1:len(s)-1]])
+		}
+	}
+	path = strings.Join(segments, "/")
+	if len(r.Query) > 0 {
+		path += "?" + r.Query.Encode()
+	}
+	return path
+}
+
+// DecodeGinResponse decodes the JSON response of the helper's handlers into resp, nil to ignore the body
+//   - envelope unwraps the data field of the success response, nil if the route does not use it
+//   - the non-2xx response is returned as *HTTPError with its Status, Code, Message and Details
+func DecodeGinResponse(envelope *GinEnvelope, status int, body []byte, resp any) error {
+	if status < 200 || status >= 300 {
+		return decodeGinHTTPError(envelope, status, body)
+	}
+	if resp == nil || len(body) == 0 {
+		return nil
+	}
+	if envelope != nil && envelope.DataField != "" {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return errors.Wrap(err, "decode envelope failed")
+		}
+		body = fields[envelope.DataField]
+	}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return errors.Wrap(err, "decode response failed")
+	}
+	return nil
+}
+
+// decodeGinHTTPError decodes the error response, the body which is not the helper's format becomes the message
+func decodeGinHTTPError(envelope *GinEnvelope, status int, body []byte) *HTTPError {
+	e := NewHTTPError(status, http.StatusText(status))
+	if envelope == nil {
+		if err := json.Unmarshal(body, e); err != nil && len(body) > 0 {
+			e.Message = string(body)
+		}
+		return e
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		if len(body) > 0 {
+			e.Message = string(body)
+		}
+		return e
+	}
+	for _, field := range []struct {
+		name string
+		v    any
+	}{
+		{envelope.CodeField, &e.Code},
+		{envelope.MessageField, &e.Message},
+		{envelope.DataField, &e.Details},
+	} {
+		if raw, ok := fields[field.name]; ok && field.name != "" {
+			_ = json.Unmarshal(raw, field.v)
+		}
+	}
+	return e
+}
+
+// ginClientInBody reports whether f is encoded in the body of bodyBinding, by the tags the binding reads
+func ginClientInBody(f reflect.StructField, bodyBinding string) bool {
+	if bodyBinding == "" {
+		return false
+	}
+	tags, ok := ginBindingTags[bodyBinding]
+	if !ok {
+		tags = []string{bodyBinding}
+	}
+	for _, tag := range tags {
+		if _, ok := ginClientTagName(f, tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ginClientTagName returns the name in the tag key of f, the field name if it is empty
+func ginClientTagName(f reflect.StructField, key string) (string, bool) {
+	tag, ok := f.Tag.Lookup(key)
+	if !ok {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return f.Name, true
+	default:
+		return name, true
+	}
+}
+
+// formatGinClientValues returns the elements of the slice v, or v itself, as strings
+func formatGinClientValues(v reflect.Value) []string {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return []string{formatGinClientValue(v)}
+	}
+	res := make([]string, v.Len())
+	for i := range res {
+		res[i] = formatGinClientValue(v.Index(i))
+	}
+	return res
+}
+
+// formatGinClientValue formats v the way the bindings parse it
+func formatGinClientValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v.Interface())
+}
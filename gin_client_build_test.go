@@ -0,0 +1,50 @@
+//go:build integration
+
+package helper_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/examples/gin/client/api"
+)
+
+// the build of the generated client needs the toolchain and the module cache,
+// run it with go test -tags integration
+var _ = Describe("Checking Client Build", Label("gin", "client"), func() {
+	It("should build the client of the routes in a module requiring this one", func() {
+		r := helper.NewGinHelper().Router(gin.New())
+		api.Register(r)
+		src, err := r.GenerateClient(func(g *helper.GinClientGenerator) {
+			g.Package = "users"
+			g.PkgPath = "github.com/fioepq9/helper_test/users"
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(ContainSubstring(`api "github.com/fioepq9/helper/examples/gin/client/api"`))
+
+		root, err := filepath.Abs(".")
+		Expect(err).NotTo(HaveOccurred())
+		sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+		Expect(err).NotTo(HaveOccurred())
+		dir := GinkgoT().TempDir()
+		for name, data := range map[string]string{
+			"go.mod": "module github.com/fioepq9/helper_test/users\n\ngo 1.21\n\n" +
+				"require github.com/fioepq9/helper v0.0.0\n\nreplace github.com/fioepq9/helper => " + root + "\n",
+			"go.sum":    string(sum),
+			"client.go": string(src),
+		} {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644)).To(Succeed())
+		}
+		cmd := exec.Command("go", "build", "./...")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+	})
+})
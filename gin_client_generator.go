@@ -0,0 +1,277 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/cockroachdb/errors"
+)
+
+// GinClientGenerator generates a typed req/v3 client of the routes registered with GinRouter.
+// The request and response types must be exported package-level types, the routes of other types
+// and the streaming routes are skipped with a comment.
+type GinClientGenerator struct {
+	// Package is the package name of the generated file, defaults to client
+	Package string
+	// PkgPath is the import path of the generated package, its types are not qualified
+	PkgPath string
+	// Name is the name of the client type, defaults to Client
+	Name string
+}
+
+func NewGinClientGenerator(options ...func(*GinClientGenerator)) *GinClientGenerator {
+	g := &GinClientGenerator{
+		Package: "client",
+		Name:    "Client",
+	}
+
+	for _, opt := range options {
+		opt(g)
+	}
+
+	return g
+}
+
+// GenerateClient returns the gofmt-ed source of the typed client of the routes registered on r
+func (r *GinRouter) GenerateClient(options ...func(*GinClientGenerator)) ([]byte, error) {
+	g := NewGinClientGenerator(options...)
+	file := ginClientFile{
+		Generator: g,
+		Envelope:  r.helper.Envelope,
+		imports:   make(map[string]string),
+	}
+	names := make(map[string]int)
+	for _, route := range r.registry.list() {
+		method, err := file.method(route)
+		if err != nil {
+			file.Skipped = append(file.Skipped, fmt.Sprintf("%s %s: %s", route.Method, route.Path, err))
+			continue
+		}
+		// Any registers the same path for many methods, the names differ by method, others are numbered
+		names[method.Name]++
+		if n := names[method.Name]; n > 1 {
+			method.Name += strconv.Itoa(n)
+		}
+		file.Methods = append(file.Methods, method)
+	}
+	for pkgPath, alias := range file.imports {
+		file.Imports = append(file.Imports, fmt.Sprintf("%s %q", alias, pkgPath))
+	}
+	sort.Strings(file.Imports)
+
+	var buf bytes.Buffer
+	if err := ginClientTemplate.Execute(&buf, file); err != nil {
+		return nil, errors.Wrap(err, "execute client template failed")
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "format client source failed\n%s", buf.String())
+	}
+	return src, nil
+}
+
+type ginClientFile struct {
+	Generator *GinClientGenerator
+	Envelope  *GinEnvelope
+	Imports   []string
+	Methods   []ginClientMethod
+	Skipped   []string
+	imports   map[string]string
+}
+
+type ginClientMethod struct {
+	Name     string
+	Method   string
+	Path     string
+	Request  string
+	Response string
+	// Body is the binding the body is encoded for, see GinClientBodyBinding
+	Body     string
+	Params   bool
+	Envelope bool
+}
+
+var helperPkgPath = reflect.TypeOf(GinRoute{}).PkgPath()
+
+var ginPathParam = regexp.MustCompile(`[:*]\w+`)
+
+// method describes the client method of route
+func (f *ginClientFile) method(route GinRoute) (ginClientMethod, error) {
+	m := ginClientMethod{
+		Method:   route.Method,
+		Path:     route.Path,
+		Body:     GinClientBodyBinding(route.Bindings),
+		Envelope: f.Envelope != nil && !route.DisableEnvelope,
+	}
+	id := openAPIOperationID(route.Method, route.Path)
+	m.Name = strings.ToUpper(id[:1]) + id[1:]
+	if route.Stream {
+		return m, errors.New("streaming routes are not supported")
+	}
+	for _, b := range route.Bindings {
+		if b == "file" {
+			return m, errors.New("file uploads are not supported")
+		}
+	}
+	var err error
+	if route.Request != nil {
+		if m.Request, err = f.typeName(route.Request); err != nil {
+			return m, err
+		}
+	} else {
+		m.Params = ginPathParam.MatchString(route.Path)
+	}
+	if route.Response != nil {
+		if m.Response, err = f.typeName(route.Response); err != nil {
+			return m, err
+		}
+	}
+	return m, nil
+}
+
+// typeName returns the qualified name of t in the generated file and records its import
+func (f *ginClientFile) typeName(t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		name, err := f.typeName(t.Elem())
+		return "*" + name, err
+	case reflect.Slice:
+		name, err := f.typeName(t.Elem())
+		return "[]" + name, err
+	case reflect.Map:
+		key, err := f.typeName(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := f.typeName(t.Elem())
+		return "map[" + key + "]" + elem, err
+	}
+	if t.Name() == "" {
+		return "", errors.Newf("type %s is not named", t)
+	}
+	if strings.Contains(t.Name(), "[") {
+		return "", errors.Newf("generic type %s is not supported", t)
+	}
+	if t.PkgPath() == "" {
+		return t.Name(), nil
+	}
+	if !token.IsExported(t.Name()) {
+		return "", errors.Newf("type %s is not exported", t)
+	}
+	if t.PkgPath() == f.Generator.PkgPath {
+		return t.Name(), nil
+	}
+	if t.PkgPath() == helperPkgPath {
+		return "helper." + t.Name(), nil
+	}
+	if t.PkgPath() == "main" || strings.HasSuffix(t.PkgPath(), "_test") {
+		return "", errors.Newf("package of type %s can not be imported", t)
+	}
+	alias, ok := f.imports[t.PkgPath()]
+	if !ok {
+		alias = f.alias(t.PkgPath())
+		f.imports[t.PkgPath()] = alias
+	}
+	return alias + "." + t.Name(), nil
+}
+
+// alias returns an unused import alias for pkgPath
+func (f *ginClientFile) alias(pkgPath string) string {
+	base := openAPINonWord.ReplaceAllString(path.Base(pkgPath), "")
+	used := map[string]bool{"context": true, "helper": true, "req": true}
+	for _, alias := range f.imports {
+		used[alias] = true
+	}
+	alias := base
+	for i := 2; used[alias]; i++ {
+		alias = base + strconv.Itoa(i)
+	}
+	return alias
+}
+
+var ginClientTemplate = template.Must(template.New("client").Parse(`// Code generated by github.com/fioepq9/helper. DO NOT EDIT.
+
+package {{.Generator.Package}}
+
+import (
+	"context"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/fioepq9/helper"
+{{range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{range .Skipped}}
+// skipped {{.}}
+{{- end}}
+
+// {{.Generator.Name}} calls the routes registered with helper.GinRouter
+type {{.Generator.Name}} struct {
+	*req.Client
+	// Envelope unwraps the responses of the routes using the envelope, nil if the server does not use it
+	Envelope *helper.GinEnvelope
+}
+
+// New{{.Generator.Name}} returns the client of baseURL
+func New{{.Generator.Name}}(baseURL string) *{{.Generator.Name}} {
+	return &{{.Generator.Name}}{
+		Client: req.C().SetBaseURL(baseURL),
+{{- with .Envelope}}
+		Envelope: &helper.GinEnvelope{
+			CodeField:    {{printf "%q" .CodeField}},
+			MessageField: {{printf "%q" .MessageField}},
+			DataField:    {{printf "%q" .DataField}},
+		},
+{{- end}}
+	}
+}
+{{range .Methods}}
+// {{.Name}} calls {{.Method}} {{.Path}}
+func (c *{{$.Generator.Name}}) {{.Name}}(ctx context.Context
+{{- if .Request}}, in *{{.Request}}{{else if .Params}}, params map[string]string{{end}}) (
+{{- if .Response}}*{{.Response}}, {{end}}error) {
+{{- if .Response}}
+	out := new({{.Response}})
+	if err := c.do(ctx, {{printf "%q" .Method}}, {{printf "%q" .Path}}, {{printf "%q" .Body}}, {{template "in" .}}, out, {{template "envelope" .}}); err != nil {
+		return nil, err
+	}
+	return out, nil
+{{- else}}
+	return c.do(ctx, {{printf "%q" .Method}}, {{printf "%q" .Path}}, {{printf "%q" .Body}}, {{template "in" .}}, nil, {{template "envelope" .}})
+{{- end}}
+}
+{{end}}
+// do sends the request encoded from in for the body binding and decodes the response into out,
+// the error responses are returned as *helper.HTTPError
+func (c *{{.Generator.Name}}) do(ctx context.Context, method, path, body string, in, out any, envelope *helper.GinEnvelope) error {
+	parts, err := helper.NewGinClientRequest(method, in, body)
+	if err != nil {
+		return err
+	}
+	r := c.R().SetContext(ctx).SetCookies(parts.Cookies...)
+	if len(parts.Header) > 0 {
+		r.Headers = parts.Header
+	}
+	if parts.Body != nil {
+		r.SetContentType(parts.ContentType).SetBodyBytes(parts.Body)
+	}
+	resp, err := r.Send(method, parts.Target(path))
+	if err != nil {
+		return err
+	}
+	return helper.DecodeGinResponse(envelope, resp.GetStatusCode(), resp.Bytes(), out)
+}
+{{define "in"}}{{if .Request}}in{{else if .Params}}params{{else}}nil{{end}}{{end}}
+{{- define "envelope"}}{{if .Envelope}}c.Envelope{{else}}nil{{end}}{{end}}
+`))
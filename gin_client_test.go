@@ -0,0 +1,200 @@
+package helper_test
+
+import (
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+type ClientGetUserRequest struct {
+	ID     int       `uri:"id"`
+	Token  string    `header:"X-Token"`
+	Fields []string  `form:"fields"`
+	Since  time.Time `form:"since"`
+	Theme  string    `cookie:"theme"`
+}
+
+type ClientUpdateUserRequest struct {
+	ID   int    `uri:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type ClientUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ClientLoginRequest struct {
+	Username string `form:"username"`
+	Remember bool   `form:"remember"`
+}
+
+type ClientNoteRequest struct {
+	ID   int      `uri:"id" json:"-" xml:"-" yaml:"-" toml:"-" codec:"-"`
+	Text string   `form:"text" json:"text" xml:"text" yaml:"text" toml:"text" codec:"text"`
+	Tags []string `form:"tags" json:"tags" xml:"tags" yaml:"tags" toml:"tags" codec:"tags"`
+}
+
+type ClientNote struct {
+	ID   int      `json:"id"`
+	Text string   `json:"text"`
+	Tags []string `json:"tags"`
+}
+
+type ClientUploadRequest struct {
+	File *multipart.FileHeader `file:"file"`
+}
+
+var _ = Describe("Checking Client", Label("gin", "client"), func() {
+	It("should encode the request fields by their tags", func() {
+		since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		parts, err := helper.NewGinClientRequest(http.MethodGet, &ClientGetUserRequest{
+			ID:     1,
+			Token:  "t",
+			Fields: []string{"a", "b"},
+			Since:  since,
+			Theme:  "dark",
+		}, "form")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parts.PathParams).To(Equal(map[string]string{"id": "1"}))
+		Expect(parts.Header.Get("X-Token")).To(Equal("t"))
+		Expect(parts.Cookies).To(HaveLen(1))
+		Expect(parts.Body).To(BeNil())
+		Expect(parts.Target("/users/:id")).To(Equal("/users/1?fields=a&fields=b&since=2024-01-02T03%3A04%3A05Z"))
+		Expect(parts.Target("/users/{id}")).To(HavePrefix("/users/1?"))
+
+		parts, err = helper.NewGinClientRequest(http.MethodPut, ClientUpdateUserRequest{ID: 2, Name: "bob"}, "json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parts.ContentType).To(Equal("application/json"))
+		Expect(parts.Body).To(MatchJSON(`{"name":"bob","age":0}`))
+		Expect(parts.Target("/users/:id")).To(Equal("/users/2"))
+
+		parts, err = helper.NewGinClientRequest(http.MethodPost, &ClientLoginRequest{Username: "alice", Remember: true}, "form")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parts.ContentType).To(Equal("application/x-www-form-urlencoded"))
+		Expect(string(parts.Body)).To(Equal("remember=true&username=alice"))
+		Expect(parts.Target("/login")).To(Equal("/login"))
+
+		parts, err = helper.NewGinClientRequest(http.MethodDelete, map[string]string{"path": "a/b"}, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parts.Target("/files/*path")).To(Equal("/files/a/b"))
+
+		_, err = helper.NewGinClientRequest(http.MethodGet, 1, "")
+		Expect(err).To(HaveOccurred())
+		_, err = helper.NewGinClientRequest(http.MethodPut, &ClientNoteRequest{}, "protobuf")
+		Expect(err).To(MatchError(ContainSubstring("body binding protobuf is not supported")))
+	})
+
+	It("should encode the body the bindings decode", func() {
+		k := helpertest.New()
+		k.Router.PUT("/notes/:id", func(c *gin.Context, req *ClientNoteRequest) (*ClientNote, error) {
+			return &ClientNote{ID: req.ID, Text: req.Text, Tags: req.Tags}, nil
+		})
+		for _, b := range []string{"json", "form", "xml", "yaml", "toml", "msgpack"} {
+			req, err := helpertest.NewRequest(http.MethodPut, "/notes/:id", &ClientNoteRequest{ID: 1, Text: "hi", Tags: []string{"a", "b"}}, b)
+			Expect(err).NotTo(HaveOccurred())
+			w := httptest.NewRecorder()
+			k.Engine.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK), b)
+			Expect(w.Body.String()).To(MatchJSON(`{"id":1,"text":"hi","tags":["a","b"]}`), b)
+		}
+	})
+
+	It("should decode the responses and the errors of the helper", func() {
+		k := helpertest.New(func(h *helper.GinHelper) {
+			h.Envelope = helper.NewGinEnvelope()
+		})
+		k.Router.GET("/users/:id", func(c *gin.Context, req *ClientGetUserRequest) (*ClientUser, error) {
+			if req.ID == 0 {
+				return nil, helper.NewHTTPError(http.StatusNotFound, "user not found")
+			}
+			return &ClientUser{ID: req.ID, Name: "alice"}, nil
+		})
+
+		w := k.Do(http.MethodGet, "/users/:id", &ClientGetUserRequest{ID: 1})
+		var user ClientUser
+		Expect(helper.DecodeGinResponse(k.Helper.Envelope, w.Code, w.Body.Bytes(), &user)).To(Succeed())
+		Expect(user).To(Equal(ClientUser{ID: 1, Name: "alice"}))
+
+		w = k.Do(http.MethodGet, "/users/:id", &ClientGetUserRequest{})
+		err := helper.DecodeGinResponse(k.Helper.Envelope, w.Code, w.Body.Bytes(), &user)
+		var httpErr *helper.HTTPError
+		Expect(err).To(BeAssignableToTypeOf(httpErr))
+		httpErr = err.(*helper.HTTPError)
+		Expect(httpErr.Status).To(Equal(http.StatusNotFound))
+		Expect(httpErr.Code).To(Equal(http.StatusNotFound))
+		Expect(httpErr.Message).To(Equal("user not found"))
+
+		err = helper.DecodeGinResponse(nil, http.StatusBadGateway, []byte("bad gateway"), nil)
+		Expect(err).To(MatchError("bad gateway"))
+	})
+
+	It("should generate the typed client of the routes", func() {
+		h := helper.NewGinHelper(func(h *helper.GinHelper) {
+			h.Envelope = helper.NewGinEnvelope()
+		})
+		r := h.Router(gin.New())
+		api := r.Group("/api")
+		api.GET("/users/:id", func(c *gin.Context, req *ClientGetUserRequest) (*ClientUser, error) {
+			return nil, nil
+		})
+		api.PUT("/users/:id", func(c *gin.Context, req *ClientUpdateUserRequest) error {
+			return nil
+		}, func(route *helper.GinRoute) {
+			route.DisableEnvelope = true
+		})
+		api.DELETE("/users/:id", func(c *gin.Context) error {
+			return nil
+		})
+		api.GET("/errors", func(c *gin.Context) (*helper.HTTPError, error) {
+			return nil, nil
+		})
+		api.GET("/events", func(c *gin.Context) (<-chan ClientUser, error) {
+			return nil, nil
+		})
+		api.POST("/login", func(c *gin.Context, req *ClientLoginRequest) error {
+			return nil
+		})
+		api.POST("/upload", func(c *gin.Context, req *ClientUploadRequest) error {
+			return nil
+		})
+		type localRequest struct{ ID int }
+		api.GET("/local", func(c *gin.Context, req *localRequest) error {
+			return nil
+		})
+
+		src, err := r.GenerateClient(func(g *helper.GinClientGenerator) {
+			g.Package = "users"
+			g.PkgPath = "github.com/fioepq9/helper_test"
+			g.Name = "UsersClient"
+		})
+		Expect(err).NotTo(HaveOccurred())
+		code := string(src)
+		Expect(code).To(HavePrefix("// Code generated by github.com/fioepq9/helper. DO NOT EDIT.\n\npackage users\n"))
+		Expect(code).To(ContainSubstring(`func NewUsersClient(baseURL string) *UsersClient {`))
+		Expect(code).To(ContainSubstring(`DataField:    "data",`))
+		Expect(code).To(ContainSubstring(
+			`func (c *UsersClient) GetApiUsersId(ctx context.Context, in *ClientGetUserRequest) (*ClientUser, error) {`))
+		Expect(code).To(ContainSubstring(`c.do(ctx, "GET", "/api/users/:id", "form", in, out, c.Envelope)`))
+		Expect(code).To(ContainSubstring(
+			`func (c *UsersClient) PutApiUsersId(ctx context.Context, in *ClientUpdateUserRequest) error {`))
+		Expect(code).To(ContainSubstring(`c.do(ctx, "PUT", "/api/users/:id", "json", in, nil, nil)`))
+		Expect(code).To(ContainSubstring(
+			`func (c *UsersClient) DeleteApiUsersId(ctx context.Context, params map[string]string) error {`))
+		Expect(code).To(ContainSubstring(
+			`func (c *UsersClient) GetApiErrors(ctx context.Context) (*helper.HTTPError, error) {`))
+		Expect(code).To(ContainSubstring(`c.do(ctx, "POST", "/api/login", "form", in, nil, c.Envelope)`))
+		Expect(code).To(ContainSubstring(`// skipped GET /api/events: streaming routes are not supported`))
+		Expect(code).To(ContainSubstring(`// skipped POST /api/upload: file uploads are not supported`))
+		Expect(code).To(ContainSubstring(`// skipped GET /api/local: type helper_test.localRequest is not exported`))
+	})
+})
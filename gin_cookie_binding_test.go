@@ -0,0 +1,89 @@
+package helper_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Cookie Binding", Label("gin", "cookie"), func() {
+	type Session struct {
+		ID string `cookie:"session_id" binding:"required"`
+	}
+
+	type CookieRequest struct {
+		Session
+		CSRF   string        `cookie:"csrf_token"`
+		Visits int           `cookie:"visits" default:"1"`
+		TTL    time.Duration `cookie:"ttl" default:"30m"`
+		SeenAt time.Time     `cookie:"seen_at"`
+		Skin   string        `cookie:"theme"`
+		Theme  string        `header:"theme"`
+	}
+
+	type CookieResponse struct {
+		ID     string    `json:"id"`
+		CSRF   string    `json:"csrf"`
+		Visits int       `json:"visits"`
+		TTL    string    `json:"ttl"`
+		SeenAt time.Time `json:"seen_at"`
+		Skin   string    `json:"skin"`
+		Theme  string    `json:"theme"`
+	}
+
+	var k *helpertest.Kit
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		k.Router.GET("/me", func(c *gin.Context, req *CookieRequest) (*CookieResponse, error) {
+			return &CookieResponse{
+				ID:     req.ID,
+				CSRF:   req.CSRF,
+				Visits: req.Visits,
+				TTL:    req.TTL.String(),
+				SeenAt: req.SeenAt,
+				Skin:   req.Skin,
+				Theme:  req.Theme,
+			}, nil
+		})
+	})
+
+	It("should bind and convert the cookies", func() {
+		w := k.Do(http.MethodGet, "/me", &CookieRequest{
+			Session: Session{ID: "s1"},
+			CSRF:    "t1",
+			Visits:  7,
+			TTL:     time.Hour,
+			SeenAt:  time.Date(2023, 6, 1, 8, 0, 0, 0, time.UTC),
+			// only the field with the cookie tag is bound, not the field with the same name
+			Skin:  "dark",
+			Theme: "light",
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"id":"s1","csrf":"t1","visits":7,"ttl":"1h0m0s","seen_at":"2023-06-01T08:00:00Z","skin":"dark","theme":"light"}`))
+	})
+
+	It("should keep the default values of the absent cookies", func() {
+		w := k.Do(http.MethodGet, "/me", &CookieRequest{Session: Session{ID: "s1"}, Theme: "light"})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"id":"s1","csrf":"","visits":1,"ttl":"30m0s","seen_at":"0001-01-01T00:00:00Z","skin":"","theme":"light"}`))
+	})
+
+	It("should validate the cookies", func() {
+		w := k.Do(http.MethodGet, "/me", &CookieRequest{})
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring(`"field":"session_id","rule":"required"`))
+	})
+
+	It("should respond 400 if a cookie can not be converted", func() {
+		w := k.Do(http.MethodGet, "/me", &CookieRequest{Session: Session{ID: "s1"}},
+			helpertest.WithCookie(&http.Cookie{Name: "visits", Value: "many"}))
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring(`bind cookie failed`))
+	})
+})
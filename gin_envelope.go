@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// GinRequestIDKey is the gin context key of the request ID
+	GinRequestIDKey = "github.com/fioepq9/helper/request_id"
+	// GinRequestIDHeader is the header which carries the request ID
+	GinRequestIDHeader = "X-Request-ID"
+)
+
+// GinEnvelope wraps the responses of the default handlers as
+// {code, message, data, request_id}
+//   - the field names can be changed, an empty name omits the field
+//   - SuccessCode and SuccessMessage are used by the default SuccessHandler
+//   - RequestID fills the request ID, defaults to GinRequestID
+type GinEnvelope struct {
+	CodeField      string
+	MessageField   string
+	DataField      string
+	RequestIDField string
+	SuccessCode    int
+	SuccessMessage string
+	RequestID      func(*gin.Context) string
+}
+
+func NewGinEnvelope(options ...func(*GinEnvelope)) *GinEnvelope {
+	e := &GinEnvelope{
+		CodeField:      "code",
+		MessageField:   "message",
+		DataField:      "data",
+		RequestIDField: "request_id",
+		SuccessCode:    0,
+		SuccessMessage: "ok",
+		RequestID:      GinRequestID,
+	}
+
+	for _, opt := range options {
+		opt(e)
+	}
+
+	return e
+}
+
+// Wrap returns the envelope body of the response
+func (e *GinEnvelope) Wrap(c *gin.Context, code int, message string, data any) gin.H {
+	h := gin.H{}
+	if e.CodeField != "" {
+		h[e.CodeField] = code
+	}
+	if e.MessageField != "" {
+		h[e.MessageField] = message
+	}
+	if e.DataField != "" {
+		h[e.DataField] = data
+	}
+	if e.RequestIDField != "" && e.RequestID != nil {
+		h[e.RequestIDField] = e.RequestID(c)
+	}
+	return h
+}
+
+// GinRequestID returns the request ID of c
+//   - it is taken from the context, then the X-Request-ID header
+//   - otherwise a new one is generated and set into the context and the response header
+func GinRequestID(c *gin.Context) string {
+	if id := c.GetString(GinRequestIDKey); id != "" {
+		return id
+	}
+	id := c.GetHeader(GinRequestIDHeader)
+	if id == "" {
+		id = uuid.NewString()
+		c.Header(GinRequestIDHeader, id)
+	}
+	c.Set(GinRequestIDKey, id)
+	return id
+}
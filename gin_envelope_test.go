@@ -0,0 +1,88 @@
+package helper_test
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Envelope", Label("gin", "envelope"), func() {
+	type EnvelopeRequest struct {
+		Name string `form:"name" binding:"required"`
+	}
+
+	type EnvelopeResponse struct {
+		Hello string `json:"hello"`
+	}
+
+	var k *helpertest.Kit
+
+	BeforeEach(func() {
+		k = helpertest.New(func(h *helper.GinHelper) {
+			h.Envelope = helper.NewGinEnvelope()
+		})
+		r := k.Router
+		r.GET("/hello", func(c *gin.Context, req *EnvelopeRequest) (*EnvelopeResponse, error) {
+			return &EnvelopeResponse{Hello: req.Name}, nil
+		})
+		r.GET("/missing", func(c *gin.Context) error {
+			return helper.NewHTTPError(http.StatusNotFound, "not found")
+		})
+		r.GET("/raw", func(c *gin.Context) (*EnvelopeResponse, error) {
+			return &EnvelopeResponse{Hello: "raw"}, nil
+		}, func(route *helper.GinRoute) {
+			route.DisableEnvelope = true
+		})
+	})
+
+	It("should wrap the success payload", func() {
+		w := k.Do(http.MethodGet, "/hello", &EnvelopeRequest{Name: "alice"},
+			helpertest.WithHeader(helper.GinRequestIDHeader, "req-1"))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"code":0,"message":"ok","data":{"hello":"alice"},"request_id":"req-1"}`))
+	})
+
+	It("should wrap the binding errors", func() {
+		w := k.Do(http.MethodGet, "/hello", &EnvelopeRequest{},
+			helpertest.WithHeader(helper.GinRequestIDHeader, "req-2"))
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(MatchJSON(`{
+			"code": 400,
+			"message": "Bad Request",
+			"data": [{"field": "name", "rule": "required", "message": "name为必填字段"}],
+			"request_id": "req-2"
+		}`))
+	})
+
+	It("should wrap the handler errors and generate the request ID", func() {
+		w := k.Do(http.MethodGet, "/missing", nil)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+		id := w.Header().Get(helper.GinRequestIDHeader)
+		Expect(id).NotTo(BeEmpty())
+		Expect(w.Body.String()).To(MatchJSON(`{"code":404,"message":"not found","data":null,"request_id":"` + id + `"}`))
+	})
+
+	It("should use the custom fields", func() {
+		k.Helper.Envelope = helper.NewGinEnvelope(func(e *helper.GinEnvelope) {
+			e.CodeField = "errno"
+			e.MessageField = "msg"
+			e.DataField = "result"
+			e.RequestIDField = ""
+			e.SuccessCode = 200
+		})
+		w := k.Do(http.MethodGet, "/hello", &EnvelopeRequest{Name: "bob"})
+		Expect(w.Body.String()).To(MatchJSON(`{"errno":200,"msg":"ok","result":{"hello":"bob"}}`))
+	})
+
+	It("should not wrap the routes which disable the envelope", func() {
+		w := k.Do(http.MethodGet, "/raw", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(strings.TrimSpace(w.Body.String())).To(MatchJSON(`{"hello":"raw"}`))
+	})
+})
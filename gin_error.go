@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// HTTPError is an error which carries the response of the request
+//   - Status: the HTTP status code
+//   - Code: the business code, defaults to Status
+//   - Message: the message for the client
+//   - Details: optional details for the client, e.g. the invalid fields
+//   - Header: optional headers of the response, e.g. Retry-After
+//
+// It can be wrapped by cockroachdb/errors, the default ErrorHandler finds it with errors.As.
+type HTTPError struct {
+	XMLName xml.Name    `json:"-" yaml:"-" toml:"-" xml:"error"`
+	Status  int         `json:"-" yaml:"-" toml:"-" xml:"-"`
+	Code    int         `json:"code" yaml:"code" toml:"code" xml:"code"`
+	Message string      `json:"message" yaml:"message" toml:"message" xml:"message"`
+	Details any         `json:"details,omitempty" yaml:"details,omitempty" toml:"details,omitempty" xml:"details,omitempty"`
+	Header  http.Header `json:"-" yaml:"-" toml:"-" xml:"-"`
+	cause   error
+}
+
+var _ error = (*HTTPError)(nil)
+
+func NewHTTPError(status int, message string, options ...func(*HTTPError)) *HTTPError {
+	e := &HTTPError{
+		Status:  status,
+		Code:    status,
+		Message: message,
+	}
+
+	for _, opt := range options {
+		opt(e)
+	}
+
+	return e
+}
+
+// WrapHTTPError wraps err with the response, err is kept as the cause
+func WrapHTTPError(err error, status int, message string, options ...func(*HTTPError)) *HTTPError {
+	e := NewHTTPError(status, message, options...)
+	e.cause = err
+	return e
+}
+
+func (e *HTTPError) Error() string {
+	if e.cause == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.cause.Error()
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.cause
+}
+
+func (e *HTTPError) Format(s fmt.State, verb rune) {
+	errors.FormatError(e, s, verb)
+}
+
+// FieldError is the validation failure of a field
+//   - Field: the external name of the field, taken from the json, form, uri or header tag
+//   - Rule: the failed rule, e.g. required
+//   - Param: the param of the rule, e.g. 10 of max=10
+//   - Message: the translated message
+type FieldError struct {
+	Field   string `json:"field" yaml:"field" toml:"field" xml:"field"`
+	Rule    string `json:"rule" yaml:"rule" toml:"rule" xml:"rule"`
+	Param   string `json:"param,omitempty" yaml:"param,omitempty" toml:"param,omitempty" xml:"param,omitempty"`
+	Message string `json:"message" yaml:"message" toml:"message" xml:"message"`
+}
+
+// ValidationError is returned by GinValidator, it has one FieldError per failed field in struct field order
+type ValidationError struct {
+	Fields  []FieldError
+	verbose bool
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		if e.verbose {
+			msgs = append(msgs, f.Field+"="+f.Message)
+		} else {
+			msgs = append(msgs, f.Message)
+		}
+	}
+	return "[" + strings.Join(msgs, ",") + "]"
+}
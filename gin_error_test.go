@@ -0,0 +1,73 @@
+package helper_test
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Error", Label("gin", "error"), func() {
+	var (
+		k   *helpertest.Kit
+		err error
+	)
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		k.Router.GET("/error", func(c *gin.Context) error {
+			return err
+		})
+	})
+
+	When("the error is an HTTPError", func() {
+		It("should respond with its status, code, message, details and headers", func() {
+			err = helper.NewHTTPError(http.StatusTooManyRequests, "slow down", func(e *helper.HTTPError) {
+				e.Code = 42901
+				e.Details = map[string]int{"limit": 10}
+				e.Header = http.Header{"Retry-After": {"30"}}
+			})
+			w := k.Do(http.MethodGet, "/error", nil)
+			Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(w.Header().Get("Retry-After")).To(Equal("30"))
+			Expect(w.Body.String()).To(MatchJSON(`{"code":42901,"message":"slow down","details":{"limit":10}}`))
+		})
+	})
+
+	When("the HTTPError is wrapped", func() {
+		It("should find it with errors.As", func() {
+			err = errors.Wrap(helper.NewHTTPError(http.StatusNotFound, "user not found"), "get user")
+			w := k.Do(http.MethodGet, "/error", nil)
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(w.Body.String()).To(MatchJSON(`{"code":404,"message":"user not found"}`))
+		})
+	})
+
+	When("the HTTPError wraps a cause", func() {
+		It("should keep the cause", func() {
+			cause := errors.New("connection refused")
+			httpErr := helper.WrapHTTPError(cause, http.StatusBadGateway, "upstream failed")
+			Expect(errors.Is(httpErr, cause)).To(BeTrue())
+			Expect(httpErr.Error()).To(Equal("upstream failed: connection refused"))
+
+			err = httpErr
+			w := k.Do(http.MethodGet, "/error", nil)
+			Expect(w.Code).To(Equal(http.StatusBadGateway))
+			Expect(w.Body.String()).To(MatchJSON(`{"code":502,"message":"upstream failed"}`))
+		})
+	})
+
+	When("the error is not an HTTPError", func() {
+		It("should respond 500 with a generic message", func() {
+			err = errors.New("secret database error")
+			w := k.Do(http.MethodGet, "/error", nil)
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			Expect(w.Body.String()).To(MatchJSON(`{"code":500,"message":"Internal Server Error"}`))
+		})
+	})
+})
@@ -0,0 +1,268 @@
+package helper
+
+import (
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	ut "github.com/go-playground/universal-translator"
+)
+
+// GinFileBinding binds the uploaded files of multipart/form-data requests into the fields with tag file
+//   - `file:"avatar"` binds *multipart.FileHeader, `file:"photos"` binds []*multipart.FileHeader
+//   - max_size: the max size of each file, e.g. `file:"avatar,max_size=2MB"`, supports B, KB, MB and GB
+//   - mime: the allowed MIME types detected from the content, e.g. `file:"avatar,mime=image/png|image/jpeg"`, supports image/*
+//   - max_count: the max count of the files, e.g. `file:"photos,max_count=3"`
+//
+// The failed constraints are returned as *ValidationError, the rule names are the option names.
+// Their messages are translated by the BindingValidator, see GinFieldTranslator.
+// Use `binding:"required"` to require the file.
+type GinFileBinding struct{}
+
+// ginFileTranslations are the messages of the failed constraints by locale, given the field name and the param
+var ginFileTranslations = map[string]map[string]string{
+	"zh": {
+		"max_size":  "{0}的大小不能超过{1}",
+		"mime":      "{0}的类型必须是[{1}]中的一个",
+		"max_count": "{0}最多只能包含{1}个文件",
+	},
+	"en": {
+		"max_size":  "{0} must not be larger than {1}",
+		"mime":      "{0} must be one of [{1}]",
+		"max_count": "{0} must contain at most {1} files",
+	},
+	"ja": {
+		"max_size":  "{0}のサイズは{1}以下にしてください",
+		"mime":      "{0}の種類は[{1}]のいずれかにしてください",
+		"max_count": "{0}のファイル数は{1}個以下にしてください",
+	},
+}
+
+// registerGinFileTranslations adds the messages of the locale of trans, or of its base language
+func registerGinFileTranslations(trans ut.Translator) error {
+	locale := trans.Locale()
+	messages, ok := ginFileTranslations[locale]
+	if !ok {
+		base, _, _ := strings.Cut(locale, "_")
+		messages = ginFileTranslations[base]
+	}
+	for rule, text := range messages {
+		if err := trans.Add(rule, text, false); err != nil {
+			return errors.Wrapf(err, "add translation %s failed", rule)
+		}
+	}
+	return nil
+}
+
+func NewGinFileBinding(options ...func(*GinFileBinding)) *GinFileBinding {
+	b := &GinFileBinding{}
+
+	for _, opt := range options {
+		opt(b)
+	}
+
+	return b
+}
+
+func (b *GinFileBinding) Name() string {
+	return "file"
+}
+
+func (b *GinFileBinding) MIMETypes() []string {
+	return []string{binding.MIMEMultipartPOSTForm}
+}
+
+func (b *GinFileBinding) Bind(c *gin.Context, obj any) error {
+	bind, err := b.Prepare(reflect.TypeOf(obj))
+	if err != nil || bind == nil {
+		return err
+	}
+	return bind(c, obj)
+}
+
+var (
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// ginFileField is a field with tag file and its parsed options
+type ginFileField struct {
+	index    []int
+	name     string
+	multiple bool
+	maxSize  int64
+	mimes    []string
+	maxCount int
+	params   map[string]string
+}
+
+func (b *GinFileBinding) Prepare(typ reflect.Type) (GinBindFunc, error) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	var fields []ginFileField
+	for _, f := range reflect.VisibleFields(typ) {
+		tag, ok := f.Tag.Lookup(b.Name())
+		if !ok || f.Anonymous || !f.IsExported() {
+			continue
+		}
+		field, err := parseGinFileField(f, tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse field %s failed", f.Name)
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return func(c *gin.Context, obj any) error {
+		if c.ContentType() != binding.MIMEMultipartPOSTForm {
+			return nil
+		}
+		form, err := c.MultipartForm()
+		if err != nil {
+			return err
+		}
+		v := reflect.ValueOf(obj).Elem()
+		var verr ValidationError
+		for _, f := range fields {
+			files := form.File[f.name]
+			if len(files) == 0 {
+				continue
+			}
+			if !f.multiple {
+				files = files[:1]
+			}
+			if rule := f.check(files); rule != "" {
+				verr.Fields = append(verr.Fields, FieldError{
+					Field: f.name,
+					Rule:  rule,
+					Param: f.params[rule],
+				})
+				continue
+			}
+			if f.multiple {
+				ginFieldByIndex(v, f.index).Set(reflect.ValueOf(files))
+			} else {
+				ginFieldByIndex(v, f.index).Set(reflect.ValueOf(files[0]))
+			}
+		}
+		if len(verr.Fields) > 0 {
+			return &verr
+		}
+		return nil
+	}, nil
+}
+
+func parseGinFileField(f reflect.StructField, tag string) (ginFileField, error) {
+	field := ginFileField{
+		index:  f.Index,
+		params: make(map[string]string),
+	}
+	switch f.Type {
+	case fileHeaderType:
+	case fileHeaderSliceType:
+		field.multiple = true
+	default:
+		return field, errors.Newf("type %s is not *multipart.FileHeader or []*multipart.FileHeader", f.Type)
+	}
+	options := strings.Split(tag, ",")
+	field.name = options[0]
+	if field.name == "" {
+		field.name = f.Name
+	}
+	for _, opt := range options[1:] {
+		key, value, _ := strings.Cut(opt, "=")
+		var err error
+		switch key {
+		case "max_size":
+			field.maxSize, err = parseGinFileSize(value)
+		case "mime":
+			field.mimes = strings.Split(value, "|")
+		case "max_count":
+			field.maxCount, err = strconv.Atoi(value)
+		default:
+			err = errors.Newf("unknown option %s", key)
+		}
+		if err != nil {
+			return field, errors.Wrapf(err, "parse option %s failed", key)
+		}
+		field.params[key] = value
+	}
+	return field, nil
+}
+
+// parseGinFileSize parses the size like 512KB, the units are 1024 based
+func parseGinFileSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+	unit := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(strings.ToUpper(s), u.suffix) {
+			s, unit = s[:len(s)-len(u.suffix)], u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n * unit, nil
+}
+
+// check returns the first failed rule of files, or "" if all the constraints are satisfied
+func (f *ginFileField) check(files []*multipart.FileHeader) string {
+	if f.maxCount > 0 && len(files) > f.maxCount {
+		return "max_count"
+	}
+	for _, fh := range files {
+		if f.maxSize > 0 && fh.Size > f.maxSize {
+			return "max_size"
+		}
+		if len(f.mimes) > 0 && !f.allowMIME(fh) {
+			return "mime"
+		}
+	}
+	return ""
+}
+
+// allowMIME detects the MIME type of the content, the declared Content-Type is not trusted
+func (f *ginFileField) allowMIME(fh *multipart.FileHeader) bool {
+	file, err := fh.Open()
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	detected, err := mimetype.DetectReader(file)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range f.mimes {
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+			if strings.HasPrefix(detected.String(), prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if detected.Is(allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package helper_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking File Binding", Label("gin", "file"), func() {
+	type UploadRequest struct {
+		Title  string                  `form:"title"`
+		Avatar *multipart.FileHeader   `file:"avatar,max_size=1KB,mime=image/png|image/jpeg" binding:"required"`
+		Photos []*multipart.FileHeader `file:"photos,max_count=2,mime=image/*"`
+	}
+
+	type UploadResponse struct {
+		Title  string   `json:"title"`
+		Avatar string   `json:"avatar"`
+		Photos []string `json:"photos"`
+	}
+
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+	var k *helpertest.Kit
+
+	type upload struct{ field, filename, content string }
+
+	// files is the multipart body of the title and the uploads
+	files := func(uploads ...upload) func(*http.Request) {
+		body := new(bytes.Buffer)
+		mw := multipart.NewWriter(body)
+		Expect(mw.WriteField("title", "hello")).To(Succeed())
+		for _, u := range uploads {
+			w, err := mw.CreateFormFile(u.field, u.filename)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Write([]byte(u.content))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(mw.Close()).To(Succeed())
+		return helpertest.WithBody(mw.FormDataContentType(), body)
+	}
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		k.Router.POST("/upload", func(c *gin.Context, req *UploadRequest) (*UploadResponse, error) {
+			resp := &UploadResponse{Title: req.Title, Avatar: req.Avatar.Filename}
+			for _, p := range req.Photos {
+				resp.Photos = append(resp.Photos, p.Filename)
+			}
+			return resp, nil
+		})
+	})
+
+	It("should bind the single and multiple files", func() {
+		w := k.Do(http.MethodPost, "/upload", nil, files(
+			upload{"avatar", "me.png", png},
+			upload{"photos", "a.png", png},
+			upload{"photos", "b.png", png},
+		))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"title":"hello","avatar":"me.png","photos":["a.png","b.png"]}`))
+	})
+
+	It("should require the file", func() {
+		w := k.Do(http.MethodPost, "/upload", nil, files())
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring(`"field":"avatar","rule":"required"`))
+	})
+
+	It("should return the failed constraints as field errors", func() {
+		w := k.Do(http.MethodPost, "/upload", nil, files(
+			upload{"avatar", "me.png", png + strings.Repeat("x", 1024)},
+			upload{"photos", "a.txt", "hello world"},
+		))
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(MatchJSON(`{
+			"code": 400,
+			"message": "Bad Request",
+			"details": [
+				{"field": "avatar", "rule": "max_size", "param": "1KB", "message": "avatar的大小不能超过1KB"},
+				{"field": "photos", "rule": "mime", "param": "image/*", "message": "photos的类型必须是[image/*]中的一个"}
+			]
+		}`))
+	})
+
+	It("should detect the MIME type from the content", func() {
+		w := k.Do(http.MethodPost, "/upload", nil, files(upload{"avatar", "fake.png", "GIF89a"}))
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring(`"rule":"mime"`))
+	})
+
+	It("should limit the count of the files", func() {
+		w := k.Do(http.MethodPost, "/upload", nil, files(
+			upload{"avatar", "me.png", png},
+			upload{"photos", "a.png", png},
+			upload{"photos", "b.png", png},
+			upload{"photos", "c.png", png},
+		))
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring(`"message":"photos最多只能包含2个文件"`))
+	})
+
+	It("should translate the messages to the locale of the request", func() {
+		w := k.Do(http.MethodPost, "/upload", nil, files(upload{"avatar", "me.png", png + strings.Repeat("x", 1024)}),
+			helpertest.WithHeader("Accept-Language", "en-US,en;q=0.9"))
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring(`"message":"avatar must not be larger than 1KB"`))
+
+		w = k.Do(http.MethodPost, "/upload", nil, files(upload{"avatar", "fake.png", "GIF89a"}),
+			helpertest.WithHeader("Accept-Language", "ja"))
+		Expect(w.Body.String()).To(ContainSubstring(`"message":"avatarの種類は[image/png|image/jpeg]のいずれかにしてください"`))
+	})
+
+	It("should bind the file of the nil embedded struct pointer", func() {
+		type Uploads struct {
+			Avatar *multipart.FileHeader `file:"avatar"`
+		}
+		type EmbeddedRequest struct {
+			*Uploads
+			Title string `form:"title"`
+		}
+		k.Router.POST("/embedded", func(c *gin.Context, req *EmbeddedRequest) (*UploadResponse, error) {
+			return &UploadResponse{Title: req.Title, Avatar: req.Avatar.Filename}, nil
+		})
+		w := k.Do(http.MethodPost, "/embedded", nil, files(upload{"avatar", "me.png", png}))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"title":"hello","avatar":"me.png","photos":null}`))
+	})
+
+	It("should panic on the invalid tag when the route is registered", func() {
+		type InvalidRequest struct {
+			Avatar string `file:"avatar"`
+		}
+		Expect(func() {
+			helper.Gin().Router(gin.New()).POST("/invalid", func(c *gin.Context, req *InvalidRequest) error {
+				return nil
+			})
+		}).To(PanicWith(MatchError(ContainSubstring("is not *multipart.FileHeader"))))
+	})
+})
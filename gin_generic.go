@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinHandlerFunc is the type-safe handler registered by the generic functions.
+// Use struct{} as Req for handlers without request.
+type GinHandlerFunc[Req, Resp any] func(c *gin.Context, req *Req) (*Resp, error)
+
+// Handle registers fn for method and path on r
+//   - the handler signature is checked by the compiler and fn is called without reflect
+//   - the request runs the same bind -> hooks -> validate -> success/error flow as GinRouter.Handle
+func Handle[Req, Resp any](r *GinRouter, method string, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	reqT := reflect.TypeOf((*Req)(nil)).Elem()
+	if reqT.Kind() != reflect.Struct {
+		panic("handler's request must be a struct")
+	}
+	plan := newGinRequestPlan(r.helper, reqT)
+
+	route := GinRoute{
+		Method:   method,
+		Path:     joinPaths(r.BasePath(), path),
+		Handler:  nameOfFunction(fn),
+		Bindings: plan.bindings(),
+		Response: reflect.TypeOf((*Resp)(nil)).Elem(),
+	}
+	if reqT.NumField() > 0 {
+		route.Request = reqT
+	}
+	for _, opt := range options {
+		opt(&route)
+	}
+
+	return r.handle(path, route, func(c *gin.Context) {
+		req := new(Req)
+		if err := plan.bind(c, req); err != nil {
+			r.onBindingError(c, &route, err)
+			return
+		}
+		resp, err := fn(c, req)
+		if err != nil {
+			r.onError(c, &route, err)
+			return
+		}
+		if resp == nil {
+			// a nil *Resp is not a nil any
+			r.onSuccess(c, &route, nil)
+			return
+		}
+		r.onSuccess(c, &route, resp)
+	})
+}
+
+func GET[Req, Resp any](r *GinRouter, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	return Handle(r, http.MethodGet, path, fn, options...)
+}
+
+func POST[Req, Resp any](r *GinRouter, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	return Handle(r, http.MethodPost, path, fn, options...)
+}
+
+func PUT[Req, Resp any](r *GinRouter, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	return Handle(r, http.MethodPut, path, fn, options...)
+}
+
+func PATCH[Req, Resp any](r *GinRouter, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	return Handle(r, http.MethodPatch, path, fn, options...)
+}
+
+func DELETE[Req, Resp any](r *GinRouter, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	return Handle(r, http.MethodDelete, path, fn, options...)
+}
+
+func HEAD[Req, Resp any](r *GinRouter, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	return Handle(r, http.MethodHead, path, fn, options...)
+}
+
+func OPTIONS[Req, Resp any](r *GinRouter, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	return Handle(r, http.MethodOptions, path, fn, options...)
+}
+
+// Any registers fn for all the methods gin.RouterGroup.Any does
+func Any[Req, Resp any](r *GinRouter, path string, fn GinHandlerFunc[Req, Resp], options ...func(*GinRoute)) *GinRouter {
+	for _, method := range ginAnyMethods {
+		Handle(r, method, path, fn, options...)
+	}
+	return r
+}
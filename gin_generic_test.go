@@ -0,0 +1,94 @@
+package helper_test
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+type genericGreetRequest struct {
+	Name     string `uri:"name" binding:"required"`
+	Greeting string `form:"greeting" default:"hello"`
+	called   []string
+}
+
+func (r *genericGreetRequest) AfterBind(c *gin.Context) error {
+	r.called = append(r.called, "AfterBind")
+	return nil
+}
+
+func (r *genericGreetRequest) AfterValidate(c *gin.Context) error {
+	r.called = append(r.called, "AfterValidate")
+	return nil
+}
+
+type genericGreetResponse struct {
+	Message string   `json:"message"`
+	Hooks   []string `json:"hooks"`
+}
+
+var _ = Describe("Checking Generic Handler", Label("gin", "generic"), func() {
+	var k *helpertest.Kit
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		r := k.Router
+		helper.GET(r, "/greet/:name", func(c *gin.Context, req *genericGreetRequest) (*genericGreetResponse, error) {
+			return &genericGreetResponse{
+				Message: req.Greeting + ", " + req.Name,
+				Hooks:   req.called,
+			}, nil
+		})
+		helper.POST(r.Group("/v2"), "/ping", func(c *gin.Context, req *struct{}) (*genericGreetResponse, error) {
+			return &genericGreetResponse{Message: "pong"}, nil
+		})
+		helper.DELETE(r, "/fail", func(c *gin.Context, req *struct{}) (*struct{}, error) {
+			return nil, errors.New("boom")
+		})
+		helper.PUT(r, "/empty", func(c *gin.Context, req *struct{}) (*struct{}, error) {
+			return nil, nil
+		})
+	})
+
+	It("should bind, run hooks and validate the request", func() {
+		w := k.Do(http.MethodGet, "/greet/:name", &genericGreetRequest{Name: "alice", Greeting: "hi"})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"message":"hi, alice","hooks":["AfterBind","AfterValidate"]}`))
+
+		w = k.Do(http.MethodGet, "/greet/:name", &genericGreetRequest{Name: "alice"})
+		Expect(w.Body.String()).To(ContainSubstring(`"message":"hello, alice"`))
+	})
+
+	It("should support handlers without request", func() {
+		w := k.Do(http.MethodPost, "/v2/ping", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"message":"pong","hooks":null}`))
+	})
+
+	It("should call the error handler when the handler fails", func() {
+		w := k.Do(http.MethodDelete, "/fail", nil)
+		Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		Expect(w.Body.String()).To(MatchJSON(`{"code":500,"message":"Internal Server Error"}`))
+	})
+
+	It("should not call the success handler when the response is nil", func() {
+		w := k.Do(http.MethodPut, "/empty", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(strings.TrimSpace(w.Body.String())).To(BeEmpty())
+	})
+
+	It("should record the routes", func() {
+		doc := helper.Gin().Router(gin.New())
+		helper.Any(doc, "/any", func(c *gin.Context, req *genericGreetRequest) (*genericGreetResponse, error) {
+			return nil, nil
+		})
+		Expect(doc.OpenAPI().Paths["/any"]).To(HaveLen(9))
+	})
+})
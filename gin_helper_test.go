@@ -0,0 +1,64 @@
+package helper_test
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Helper Instances", Label("gin", "instance"), func() {
+	type LoginRequest struct {
+		Device   string `header:"X-Device" default:"web"`
+		Username string `json:"username" binding:"required"`
+	}
+
+	type LoginResponse struct {
+		Device   string `json:"device"`
+		Username string `json:"username"`
+	}
+
+	var k *helpertest.Kit
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		login := func(c *gin.Context, req *LoginRequest) (*LoginResponse, error) {
+			return &LoginResponse{Device: req.Device, Username: req.Username}, nil
+		}
+		fail := func(c *gin.Context) error {
+			return helper.NewHTTPError(http.StatusForbidden, "forbidden")
+		}
+		v2 := helper.NewGinHelper(func(h *helper.GinHelper) {
+			h.ErrorHandler = func(c *gin.Context, err error) {
+				c.AbortWithStatusJSON(http.StatusTeapot, gin.H{"error": err.Error()})
+			}
+		})
+		k.Router.Group("/v1").POST("/login", login).GET("/fail", fail)
+		v2.Router(k.Engine.Group("/v2")).POST("/login", login).GET("/fail", fail)
+	})
+
+	It("should keep the options of each instance", func() {
+		Expect(k.Do(http.MethodGet, "/v1/fail", nil).Body.String()).To(MatchJSON(`{"code":403,"message":"forbidden"}`))
+		w := k.Do(http.MethodGet, "/v2/fail", nil)
+		Expect(w.Code).To(Equal(http.StatusTeapot))
+		Expect(w.Body.String()).To(MatchJSON(`{"error":"forbidden"}`))
+		Expect(helper.NewGinHelper()).NotTo(BeIdenticalTo(helper.NewGinHelper()))
+		Expect(helper.Gin()).To(BeIdenticalTo(helper.Gin()))
+	})
+
+	It("should not disable the gin validation", func() {
+		helper.Gin()
+		Expect(binding.Validator).NotTo(BeNil())
+	})
+
+	It("should validate once after all the bindings", func() {
+		w := k.Do(http.MethodPost, "/v1/login", &LoginRequest{Username: "alice"})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"device":"web","username":"alice"}`))
+	})
+})
@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// GinLoggerKey is the gin context key of the request logger
+const GinLoggerKey = "github.com/fioepq9/helper/logger"
+
+// GinRequestLogger builds the request logger with the fields request_id, method, route and client_ip
+type GinRequestLogger struct {
+	// Logger is the parent of the request loggers, nil to use the logger of the helper
+	Logger *zerolog.Logger
+	// RequestID returns the request ID, defaults to GinRequestID
+	RequestID func(*gin.Context) string
+	// Fields adds more fields to the request logger, nil to add nothing
+	Fields func(c *gin.Context, ctx zerolog.Context) zerolog.Context
+}
+
+func NewGinRequestLogger(options ...func(*GinRequestLogger)) *GinRequestLogger {
+	l := &GinRequestLogger{
+		RequestID: GinRequestID,
+	}
+
+	for _, opt := range options {
+		opt(l)
+	}
+
+	return l
+}
+
+// RequestLogger returns the middleware which stores the request logger in the gin context and c.Request.Context(),
+// so the handlers, GormZerologLogger and the access log share its fields.
+// The request ID is written to the X-Request-ID response header.
+func (h *GinHelper) RequestLogger(options ...func(*GinRequestLogger)) gin.HandlerFunc {
+	l := NewGinRequestLogger(options...)
+	return func(c *gin.Context) {
+		parent := l.Logger
+		if parent == nil {
+			parent = h.logger(c)
+		}
+		ctx := parent.With()
+		if l.RequestID != nil {
+			id := l.RequestID(c)
+			c.Header(GinRequestIDHeader, id)
+			ctx = ctx.Str("request_id", id)
+		}
+		ctx = ctx.
+			Str("method", c.Request.Method).
+			Str("route", c.FullPath()).
+			Str("client_ip", c.ClientIP())
+		if l.Fields != nil {
+			ctx = l.Fields(c, ctx)
+		}
+		logger := ctx.Logger()
+		c.Set(GinLoggerKey, &logger)
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context()))
+		c.Next()
+	}
+}
+
+// GinLogger returns the request logger of c, or the logger of c.Request.Context(), see zerolog.Ctx
+func GinLogger(c *gin.Context) *zerolog.Logger {
+	if v, ok := c.Get(GinLoggerKey); ok {
+		if logger, ok := v.(*zerolog.Logger); ok {
+			return logger
+		}
+	}
+	return zerolog.Ctx(c.Request.Context())
+}
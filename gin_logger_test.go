@@ -0,0 +1,84 @@
+package helper_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Request Logger", Label("gin", "logger"), func() {
+	var (
+		k   *helpertest.Kit
+		r   *helper.GinRouter
+		buf *bytes.Buffer
+	)
+
+	lines := func() []map[string]any {
+		var res []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			m := make(map[string]any)
+			Expect(json.Unmarshal([]byte(line), &m)).To(Succeed())
+			res = append(res, m)
+		}
+		return res
+	}
+
+	BeforeEach(func() {
+		buf = new(bytes.Buffer)
+		k = helpertest.New(func(h *helper.GinHelper) {
+			log := zerolog.New(buf)
+			h.Logger = &log
+		})
+		k.Engine.Use(k.Helper.RequestLogger())
+		r = k.Router
+	})
+
+	It("should share the fields between the handler and gorm logs", func() {
+		r.GET("/users/:id", func(ctx context.Context, log *zerolog.Logger) error {
+			log.Info().Msg("handler")
+			helper.NewGormZerologLogger().Info(ctx, "gorm")
+			return nil
+		})
+
+		w := k.Do(http.MethodGet, "/users/1", nil, helpertest.WithHeader(helper.GinRequestIDHeader, "req-1"), func(r *http.Request) {
+			r.RemoteAddr = "10.0.0.1:1234"
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get(helper.GinRequestIDHeader)).To(Equal("req-1"))
+
+		logs := lines()
+		Expect(logs).To(HaveLen(2))
+		for _, log := range logs {
+			Expect(log).To(HaveKeyWithValue("request_id", "req-1"))
+			Expect(log).To(HaveKeyWithValue("method", "GET"))
+			Expect(log).To(HaveKeyWithValue("route", "/users/:id"))
+			Expect(log).To(HaveKeyWithValue("client_ip", "10.0.0.1"))
+		}
+		Expect(logs[0]).To(HaveKeyWithValue("message", "handler"))
+		Expect(logs[1]).To(HaveKeyWithValue("message", "gorm"))
+	})
+
+	It("should generate the request ID and store the logger in the gin context", func() {
+		var id string
+		r.GET("/id", func(c *gin.Context) error {
+			id = helper.GinRequestID(c)
+			helper.GinLogger(c).Info().Msg("gin")
+			return nil
+		})
+
+		w := k.Do(http.MethodGet, "/id", nil)
+		Expect(id).NotTo(BeEmpty())
+		Expect(w.Header().Get(helper.GinRequestIDHeader)).To(Equal(id))
+		Expect(lines()[0]).To(HaveKeyWithValue("request_id", id))
+	})
+})
@@ -0,0 +1,495 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// OpenAPI is an OpenAPI 3.1 document
+type OpenAPI struct {
+	OpenAPI    string                     `json:"openapi" yaml:"openapi"`
+	Info       OpenAPIInfo                `json:"info" yaml:"info"`
+	Servers    []OpenAPIServer            `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths      map[string]OpenAPIPathItem `json:"paths" yaml:"paths"`
+	Components *OpenAPIComponents         `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type OpenAPIServer struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// OpenAPIPathItem maps the lower case method to its operation
+type OpenAPIPathItem map[string]*OpenAPIOperation
+
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Summary     string                     `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses" yaml:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+type OpenAPIParameter struct {
+	Name     string         `json:"name" yaml:"name"`
+	In       string         `json:"in" yaml:"in"`
+	Required bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *OpenAPISchema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]OpenAPIMediaType `json:"content" yaml:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                      `json:"description" yaml:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+}
+
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string                    `json:"format,omitempty" yaml:"format,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty" yaml:"required,omitempty"`
+	Default              any                       `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// JSON encodes the document as JSON
+func (d *OpenAPI) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML encodes the document as YAML
+func (d *OpenAPI) YAML() ([]byte, error) {
+	return yaml.Marshal(d)
+}
+
+// OpenAPI builds the OpenAPI document of the routes registered through r and its groups
+func (r *GinRouter) OpenAPI(options ...func(*OpenAPI)) *OpenAPI {
+	doc := &OpenAPI{
+		OpenAPI: "3.1.0",
+		Info: OpenAPIInfo{
+			Title:   "API",
+			Version: "1.0.0",
+		},
+		Paths: make(map[string]OpenAPIPathItem),
+	}
+	for _, opt := range options {
+		opt(doc)
+	}
+
+	g := &openAPIGenerator{
+		schemas: make(map[string]*OpenAPISchema),
+		names:   make(map[reflect.Type]string),
+	}
+	for _, route := range r.registry.list() {
+		path := openAPIPath(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(OpenAPIPathItem)
+			doc.Paths[path] = item
+		}
+		offers := r.helper.Offers
+		if len(offers) == 0 {
+			offers = GinDefaultOffers
+		}
+		item[strings.ToLower(route.Method)] = g.operation(route, offers)
+	}
+	if len(g.schemas) > 0 {
+		doc.Components = &OpenAPIComponents{Schemas: g.schemas}
+	}
+	return doc
+}
+
+// ServeOpenAPI registers the document and its docs page on r
+//   - GET {path}: docs page
+//   - GET {path}/openapi.json
+//   - GET {path}/openapi.yaml
+//
+// The document is built on every request, so routes registered later are included.
+func (r *GinRouter) ServeOpenAPI(path string, options ...func(*OpenAPI)) *GinRouter {
+	jsonPath := joinPaths(path, "openapi.json")
+	r.routes.GET(path, func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(fmt.Sprintf(openAPIDocsHTML, joinPaths(r.BasePath(), jsonPath))))
+	})
+	r.routes.GET(jsonPath, func(c *gin.Context) {
+		data, err := r.OpenAPI(options...).JSON()
+		if err != nil {
+			_ = c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+	})
+	r.routes.GET(joinPaths(path, "openapi.yaml"), func(c *gin.Context) {
+		data, err := r.OpenAPI(options...).YAML()
+		if err != nil {
+			_ = c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		c.Data(http.StatusOK, "application/yaml; charset=utf-8", data)
+	})
+	return r
+}
+
+const openAPIDocsHTML = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>window.ui = SwaggerUIBundle({url: %q, dom_id: "#swagger-ui"});</script>
+</body>
+</html>
+`
+
+var openAPIPathParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// openAPIPath converts the gin path params to the OpenAPI ones, e.g. /users/:id -> /users/{id}
+func openAPIPath(path string) string {
+	return openAPIPathParam.ReplaceAllString(path, "{$1}")
+}
+
+var openAPINonWord = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// openAPIOperationID derives the operation id from the route, e.g. GET /users/:id -> getUsersId
+func openAPIOperationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, word := range strings.Fields(openAPINonWord.ReplaceAllString(path, " ")) {
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(word[1:])
+	}
+	return b.String()
+}
+
+type openAPIGenerator struct {
+	schemas map[string]*OpenAPISchema
+	names   map[reflect.Type]string
+}
+
+// operation describes route, its responses have a content per offered format
+//   - the item schema of the streaming route is described per stream format
+func (g *openAPIGenerator) operation(route GinRoute, offers []string) *OpenAPIOperation {
+	successOffers := offers
+	switch {
+	case route.Stream && len(route.Offers) > 0:
+		successOffers = route.Offers
+	case route.Stream:
+		successOffers = GinStreamOffers
+	case len(route.Offers) > 0:
+		offers, successOffers = route.Offers, route.Offers
+	}
+	op := &OpenAPIOperation{
+		OperationID: openAPIOperationID(route.Method, route.Path),
+		Summary:     route.Summary,
+		Description: route.Description,
+		Tags:        route.Tags,
+		Deprecated:  route.Deprecated,
+		Responses:   make(map[string]OpenAPIResponse),
+	}
+	if route.Request != nil {
+		op.Parameters = g.parameters(route.Request)
+		if body := g.fieldsSchema(route.Request, "json"); body != nil {
+			op.RequestBody = &OpenAPIRequestBody{
+				Required: len(body.Required) > 0,
+				Content: map[string]OpenAPIMediaType{
+					"application/json": {Schema: body},
+				},
+			}
+		}
+		if files := g.fieldsSchema(route.Request, "file"); files != nil {
+			if op.RequestBody == nil {
+				op.RequestBody = &OpenAPIRequestBody{Content: make(map[string]OpenAPIMediaType)}
+			}
+			op.RequestBody.Required = op.RequestBody.Required || len(files.Required) > 0
+			op.RequestBody.Content["multipart/form-data"] = OpenAPIMediaType{Schema: files}
+		}
+		op.Responses[strconv.Itoa(http.StatusBadRequest)] = OpenAPIResponse{
+			Description: http.StatusText(http.StatusBadRequest),
+		}
+	}
+	status := http.StatusOK
+	if route.Status != 0 {
+		status = route.Status
+	}
+	success := OpenAPIResponse{Description: http.StatusText(status)}
+	if route.Response != nil && status != http.StatusNoContent {
+		success.Content = openAPIContent(successOffers, g.schema(route.Response))
+	}
+	op.Responses[strconv.Itoa(status)] = success
+	op.Responses["default"] = OpenAPIResponse{
+		Description: "Error",
+		Content:     openAPIContent(offers, g.schema(httpErrorType)),
+	}
+	return op
+}
+
+func openAPIContent(offers []string, schema *OpenAPISchema) map[string]OpenAPIMediaType {
+	content := make(map[string]OpenAPIMediaType, len(offers))
+	for _, offer := range offers {
+		content[offer] = OpenAPIMediaType{Schema: schema}
+	}
+	return content
+}
+
+// openAPIParameterTags maps the binding tags to the parameter locations
+var openAPIParameterTags = []struct{ tag, in string }{
+	{"uri", "path"},
+	{"header", "header"},
+	{"cookie", "cookie"},
+	{"form", "query"},
+}
+
+func (g *openAPIGenerator) parameters(typ reflect.Type) []OpenAPIParameter {
+	var params []OpenAPIParameter
+	for _, pt := range openAPIParameterTags {
+		walkTaggedFields(typ, pt.tag, false, func(f reflect.StructField, name string) {
+			params = append(params, OpenAPIParameter{
+				Name:     name,
+				In:       pt.in,
+				Required: pt.in == "path" || isRequiredField(f),
+				Schema:   g.parameterSchema(f),
+			})
+		})
+	}
+	return params
+}
+
+// parameterSchema is the schema of the parameter f, the durations are parsed from strings like 5s
+// instead of the nanoseconds of their JSON encoding
+func (g *openAPIGenerator) parameterSchema(f reflect.StructField) *OpenAPISchema {
+	t := f.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != durationType {
+		return g.fieldSchema(f)
+	}
+	s := &OpenAPISchema{Type: "string", Format: "duration"}
+	if def, ok := f.Tag.Lookup("default"); ok {
+		s.Default = def
+	}
+	return s
+}
+
+// fieldsSchema builds an inline object schema from the fields of typ which have tag
+func (g *openAPIGenerator) fieldsSchema(typ reflect.Type, tag string) *OpenAPISchema {
+	var s *OpenAPISchema
+	walkTaggedFields(typ, tag, false, func(f reflect.StructField, name string) {
+		if s == nil {
+			s = &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+		}
+		s.Properties[name] = g.fieldSchema(f)
+		if isRequiredField(f) {
+			s.Required = append(s.Required, name)
+		}
+	})
+	return s
+}
+
+func (g *openAPIGenerator) fieldSchema(f reflect.StructField) *OpenAPISchema {
+	s := g.schema(f.Type)
+	if def, ok := f.Tag.Lookup("default"); ok && s.Ref == "" {
+		// copy, so the default does not leak into a shared schema
+		c := *s
+		c.Default = openAPIDefault(f.Type, def)
+		s = &c
+	}
+	return s
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	bytesType    = reflect.TypeOf([]byte(nil))
+
+	httpErrorType = reflect.TypeOf(HTTPError{})
+)
+
+func (g *openAPIGenerator) schema(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case bytesType:
+		return &OpenAPISchema{Type: "string", Format: "byte"}
+	case fileHeaderType.Elem():
+		return &OpenAPISchema{Type: "string", Format: "binary"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &OpenAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + g.component(t)}
+	default:
+		return &OpenAPISchema{}
+	}
+}
+
+// component registers the named struct t in the components and returns its name
+func (g *openAPIGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	base := strings.Trim(openAPINonWord.ReplaceAllString(t.Name(), "_"), "_")
+	name := base
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.names[t] = name
+	// reserve the name before walking the fields, so recursive types terminate
+	g.schemas[name] = &OpenAPISchema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+func (g *openAPIGenerator) structSchema(t reflect.Type) *OpenAPISchema {
+	s := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	walkTaggedFields(t, "json", true, func(f reflect.StructField, name string) {
+		s.Properties[name] = g.fieldSchema(f)
+		if isRequiredField(f) {
+			s.Required = append(s.Required, name)
+		}
+	})
+	return s
+}
+
+// walkTaggedFields calls fn with the exported fields of typ and its embedded structs which have tag.
+// The name is taken from tag, if untagged is true, fields without tag are walked too
+// and fall back to the field name as encoding/json does.
+func walkTaggedFields(typ reflect.Type, tag string, untagged bool, fn func(f reflect.StructField, name string)) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		value, ok := f.Tag.Lookup(tag)
+		name, _, _ := strings.Cut(value, ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			walkTaggedFields(f.Type, tag, untagged, fn)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if !ok && !untagged {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fn(f, name)
+	}
+}
+
+func isRequiredField(f reflect.StructField) bool {
+	for _, rule := range strings.Split(f.Tag.Get("binding"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// openAPIDefault converts the default tag to the value of the field's type when possible
+func openAPIDefault(t reflect.Type, def string) any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		if _, err := time.Parse(time.RFC3339Nano, def); err != nil {
+			return nil
+		}
+		return def
+	case durationType:
+		if d, err := time.ParseDuration(def); err == nil {
+			return int64(d)
+		}
+		return nil
+	case bytesType:
+		return def
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return def == "true"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if i, err := strconv.ParseInt(def, 10, 64); err == nil {
+			return i
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(def, 64); err == nil {
+			return f
+		}
+	case reflect.Slice:
+		items := strings.Split(def, ",")
+		res := make([]any, 0, len(items))
+		for _, item := range items {
+			res = append(res, openAPIDefault(t.Elem(), item))
+		}
+		return res
+	}
+	return def
+}
@@ -0,0 +1,116 @@
+package helper_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking OpenAPI", Label("gin", "openapi"), func() {
+	type User struct {
+		Name    string  `json:"name"`
+		Friends []*User `json:"friends,omitempty"`
+	}
+
+	type GetUserRequest struct {
+		Token   string        `header:"token" binding:"required"`
+		ID      int           `uri:"id"`
+		Limit   int           `form:"limit" default:"10"`
+		Timeout time.Duration `form:"timeout" default:"5s"`
+	}
+
+	type CreateUserRequest struct {
+		Token string `header:"token"`
+		Name  string `json:"name" binding:"required"`
+		Age   int    `json:"age" default:"18"`
+	}
+
+	var (
+		k *helpertest.Kit
+		r *helper.GinRouter
+	)
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		r = k.Router
+		api := r.Group("/api")
+		api.GET("/users/:id", func(c *gin.Context, req *GetUserRequest) (*User, error) {
+			return &User{}, nil
+		})
+		api.POST("/users", func(c *gin.Context, req *CreateUserRequest) (*User, error) {
+			return &User{}, nil
+		})
+		api.DELETE("/users/:id", func(c *gin.Context) error {
+			return nil
+		})
+		r.ServeOpenAPI("/docs", func(doc *helper.OpenAPI) {
+			doc.Info.Title = "users"
+		})
+	})
+
+	It("should describe the parameters, body and response of the routes", func() {
+		doc := r.OpenAPI()
+		Expect(doc.OpenAPI).To(Equal("3.1.0"))
+		Expect(doc.Paths).To(HaveKey("/api/users/{id}"))
+		Expect(doc.Paths).To(HaveKey("/api/users"))
+
+		get := doc.Paths["/api/users/{id}"]["get"]
+		Expect(get.OperationID).To(Equal("getApiUsersId"))
+		Expect(get.Parameters).To(ConsistOf(
+			helper.OpenAPIParameter{Name: "id", In: "path", Required: true, Schema: &helper.OpenAPISchema{Type: "integer", Format: "int64"}},
+			helper.OpenAPIParameter{Name: "token", In: "header", Required: true, Schema: &helper.OpenAPISchema{Type: "string"}},
+			helper.OpenAPIParameter{Name: "limit", In: "query", Schema: &helper.OpenAPISchema{Type: "integer", Format: "int64", Default: int64(10)}},
+			helper.OpenAPIParameter{Name: "timeout", In: "query", Schema: &helper.OpenAPISchema{Type: "string", Format: "duration", Default: "5s"}},
+		))
+		Expect(get.RequestBody).To(BeNil())
+		Expect(get.Responses["200"].Content["application/json"].Schema.Ref).To(Equal("#/components/schemas/User"))
+
+		post := doc.Paths["/api/users"]["post"]
+		Expect(post.RequestBody.Required).To(BeTrue())
+		body := post.RequestBody.Content["application/json"].Schema
+		Expect(body.Properties).To(HaveKey("name"))
+		Expect(body.Properties).To(HaveKey("age"))
+		Expect(body.Properties).NotTo(HaveKey("token"))
+		Expect(body.Required).To(Equal([]string{"name"}))
+		Expect(body.Properties["age"].Default).To(Equal(int64(18)))
+
+		del := doc.Paths["/api/users/{id}"]["delete"]
+		Expect(del.Parameters).To(BeEmpty())
+		Expect(del.Responses["200"].Content).To(BeEmpty())
+
+		Expect(del.Responses["default"].Content["application/json"].Schema.Ref).To(Equal("#/components/schemas/HTTPError"))
+		Expect(doc.Components.Schemas["HTTPError"].Properties).To(HaveKey("code"))
+		Expect(doc.Components.Schemas["HTTPError"].Properties).To(HaveKey("message"))
+
+		user := doc.Components.Schemas["User"]
+		Expect(user.Properties["friends"].Items.Ref).To(Equal("#/components/schemas/User"))
+	})
+
+	It("should serve the document as JSON and YAML", func() {
+		w := k.Do(http.MethodGet, "/docs/openapi.json", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var fromJSON map[string]any
+		Expect(json.Unmarshal(w.Body.Bytes(), &fromJSON)).To(Succeed())
+		Expect(fromJSON).To(HaveKeyWithValue("info", HaveKeyWithValue("title", "users")))
+
+		w = k.Do(http.MethodGet, "/docs/openapi.yaml", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var fromYAML map[string]any
+		Expect(yaml.Unmarshal(w.Body.Bytes(), &fromYAML)).To(Succeed())
+		Expect(fromYAML).To(HaveKeyWithValue("paths", HaveKey("/api/users")))
+	})
+
+	It("should serve the docs page", func() {
+		w := k.Do(http.MethodGet, "/docs", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(`"/docs/openapi.json"`))
+	})
+})
@@ -0,0 +1,239 @@
+package helper
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ginRequestPlan is the binding and validation plan of a request type.
+// It is built once when the route is registered, so the request path only
+// runs the precomputed steps.
+type ginRequestPlan struct {
+	helper         *GinHelper
+	typ            reflect.Type
+	steps          []ginBindStep
+	body           bool
+	bodyOnly       bool
+	beforeBind     bool
+	afterBind      bool
+	beforeValidate bool
+	afterValidate  bool
+}
+
+type ginBindStep struct {
+	name string
+	bind GinBindFunc
+	// mimes are the Content-Types of GinBodyBinding, nil for the other bindings
+	mimes []string
+}
+
+// accepts reports whether the step runs for the request Content-Type
+func (s *ginBindStep) accepts(contentType string) bool {
+	if s.mimes == nil {
+		return true
+	}
+	for _, mime := range s.mimes {
+		if mime == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	beforeBindingType    = reflect.TypeOf((*BeforeBinding)(nil)).Elem()
+	afterBindingType     = reflect.TypeOf((*AfterBinding)(nil)).Elem()
+	beforeValidationType = reflect.TypeOf((*BeforeValidation)(nil)).Elem()
+	afterValidationType  = reflect.TypeOf((*AfterValidation)(nil)).Elem()
+)
+
+// newGinRequestPlan builds the plan of typ, which must be a struct type
+func newGinRequestPlan(h *GinHelper, typ reflect.Type) *ginRequestPlan {
+	ptr := reflect.PointerTo(typ)
+	p := &ginRequestPlan{
+		helper:         h,
+		typ:            typ,
+		beforeBind:     ptr.Implements(beforeBindingType),
+		afterBind:      ptr.Implements(afterBindingType),
+		beforeValidate: ptr.Implements(beforeValidationType),
+		afterValidate:  ptr.Implements(afterValidationType),
+	}
+	for _, b := range h.Bindings {
+		step := ginBindStep{name: b.Name()}
+		if bb, ok := b.(GinBodyBinding); ok {
+			step.mimes = bb.MIMETypes()
+		}
+		if pb, ok := b.(GinPreparedBinding); ok {
+			bind, err := pb.Prepare(typ)
+			if err != nil {
+				panic(errors.Wrapf(err, "prepare binding %s for %s failed", b.Name(), typ))
+			}
+			step.bind = bind
+		} else if ginHasTag(typ, b.Name()) {
+			step.bind = b.Bind
+		}
+		if step.bind == nil {
+			continue
+		}
+		p.steps = append(p.steps, step)
+		if step.mimes != nil {
+			p.body = true
+			p.bodyOnly = p.bodyOnly || !ginQueryBodyBindings[step.name]
+		}
+	}
+	return p
+}
+
+// bindings returns the names of the bindings which have steps
+func (p *ginRequestPlan) bindings() []string {
+	names := make([]string, 0, len(p.steps))
+	for _, step := range p.steps {
+		names = append(names, step.name)
+	}
+	return names
+}
+
+// bind runs the hooks, bindings and validation on obj, which must be a pointer to the plan's type
+func (p *ginRequestPlan) bind(c *gin.Context, obj any) error {
+	// call BeforeBind hook
+	if p.beforeBind {
+		if err := obj.(BeforeBinding).BeforeBind(c); err != nil {
+			return errors.Wrap(err, "hook BeforeBind failed")
+		}
+	}
+	// bind, the body bindings are chosen by Content-Type and read the cached body
+	contentType := c.ContentType()
+	var body []byte
+	if p.body && ginHasBody(c.Request) {
+		// the body without Content-Type is bound as JSON, as binding.Default does
+		if contentType == "" {
+			contentType = binding.MIMEJSON
+		}
+		accepted := p.accepts(contentType)
+		// the form binding also reads the query, so only the body-only bindings reject the other Content-Types
+		if !accepted && p.bodyOnly {
+			return NewHTTPError(http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+		}
+		// multipart is parsed once into c.Request.MultipartForm, which is not worth caching
+		if accepted && contentType != binding.MIMEMultipartPOSTForm {
+			var err error
+			if body, err = ginCacheBody(c); err != nil {
+				return errors.Wrap(err, "read body failed")
+			}
+		}
+	}
+	for _, step := range p.steps {
+		if !step.accepts(contentType) {
+			continue
+		}
+		if step.mimes != nil && body != nil {
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		if err := step.bind(c, obj); err != nil {
+			p.translate(c, err)
+			return errors.Wrapf(err, "bind %s failed", step.name)
+		}
+	}
+	if body != nil {
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	// call AfterBind hook
+	if p.afterBind {
+		if err := obj.(AfterBinding).AfterBind(c); err != nil {
+			return errors.Wrap(err, "hook AfterBind failed")
+		}
+	}
+	// call BeforeValidate hook
+	if p.beforeValidate {
+		if err := obj.(BeforeValidation).BeforeValidate(c); err != nil {
+			return errors.Wrap(err, "hook BeforeValidate failed")
+		}
+	}
+	// validate
+	var err error
+	if cv, ok := p.helper.BindingValidator.(GinContextValidator); ok {
+		err = cv.ValidateStructContext(c, obj)
+	} else {
+		err = p.helper.BindingValidator.ValidateStruct(obj)
+	}
+	if err != nil {
+		return errors.Wrap(err, "validate failed")
+	}
+	// call AfterValidate hook
+	if p.afterValidate {
+		if err := obj.(AfterValidation).AfterValidate(c); err != nil {
+			return errors.Wrap(err, "hook AfterValidate failed")
+		}
+	}
+	return nil
+}
+
+// accepts reports whether one of the body bindings reads contentType
+func (p *ginRequestPlan) accepts(contentType string) bool {
+	for _, step := range p.steps {
+		if step.mimes != nil && step.accepts(contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// translate sets the messages of the field errors returned by the bindings with the BindingValidator
+func (p *ginRequestPlan) translate(c *gin.Context, err error) {
+	translator, ok := p.helper.BindingValidator.(GinFieldTranslator)
+	var verr *ValidationError
+	if !ok || !errors.As(err, &verr) {
+		return
+	}
+	for i := range verr.Fields {
+		if verr.Fields[i].Message == "" {
+			translator.TranslateField(c, &verr.Fields[i])
+		}
+	}
+}
+
+// ginHasBody reports whether the request may have a body
+func ginHasBody(req *http.Request) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+}
+
+// ginCacheBody reads the body once and keeps it in gin.BodyBytesKey, as c.ShouldBindBodyWith does
+func ginCacheBody(c *gin.Context) ([]byte, error) {
+	if cached, ok := c.Get(gin.BodyBytesKey); ok {
+		if body, ok := cached.([]byte); ok {
+			return body, nil
+		}
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.Set(gin.BodyBytesKey, body)
+	return body, nil
+}
+
+// ginHasTag reports whether typ or one of its embedded structs has a field with tag
+func ginHasTag(typ reflect.Type, tag string) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if _, ok := f.Tag.Lookup(tag); ok {
+			return true
+		}
+		if f.Anonymous && ginHasTag(f.Type, tag) {
+			return true
+		}
+	}
+	return false
+}
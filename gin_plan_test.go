@@ -0,0 +1,162 @@
+package helper_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/mitchellh/mapstructure"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Plan", Label("gin", "plan"), func() {
+	type PlanRequest struct {
+		Token   string        `header:"token" binding:"required"`
+		Limit   int           `form:"limit" default:"10"`
+		Order   string        `form:"order" default:"asc"`
+		Names   []string      `form:"names" default:"alice,bob,charlie"`
+		Timeout time.Duration `form:"timeout" default:"5s"`
+		Today   time.Time     `form:"today" default:"now"`
+	}
+
+	type PlanResponse struct {
+		Token string `json:"token"`
+		Limit int    `json:"limit"`
+	}
+
+	var k *helpertest.Kit
+
+	// request is sent to both routes, the header token and the query limit=20
+	request := &PlanRequest{Token: "foo", Limit: 20}
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		h := k.Helper
+		k.Router.GET("/planned", func(c *gin.Context, req *PlanRequest) (*PlanResponse, error) {
+			return &PlanResponse{Token: req.Token, Limit: req.Limit}, nil
+		})
+		// unplanned is the baseline flow before the plan was introduced: the default, header, uri, form
+		// and json bindings which are looked up by the tags of the request type on each request,
+		// with the gin validation disabled and one validation after all the bindings.
+		bindings := []helper.GinBinding{
+			&baselineDefaultBinding{helper.NewGinDefaultBinding()},
+			helper.NewGinBinding(binding.Header),
+			helper.NewGinURIBinding(),
+			helper.NewGinBinding(binding.Form),
+			helper.NewGinBinding(binding.JSON),
+		}
+		k.Engine.GET("/unplanned", func(c *gin.Context) {
+			req := new(PlanRequest)
+			typ := reflect.TypeOf(req).Elem()
+			hasTags := map[string]bool{"default": true}
+			for i := 0; i < typ.NumField(); i++ {
+				for _, b := range bindings {
+					if _, ok := typ.Field(i).Tag.Lookup(b.Name()); ok {
+						hasTags[b.Name()] = true
+					}
+				}
+			}
+			for _, b := range bindings {
+				if !hasTags[b.Name()] {
+					continue
+				}
+				if err := b.Bind(c, req); err != nil {
+					c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+					return
+				}
+			}
+			if err := h.BindingValidator.ValidateStruct(req); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+				return
+			}
+			c.JSON(http.StatusOK, &PlanResponse{Token: req.Token, Limit: req.Limit})
+		})
+		validator := binding.Validator
+		binding.Validator = nil
+		DeferCleanup(func() {
+			binding.Validator = validator
+		})
+	})
+
+	It("should bind the same as the unplanned route", func() {
+		planned, unplanned := k.Do(http.MethodGet, "/planned", request), k.Do(http.MethodGet, "/unplanned", request)
+		Expect(planned.Code).To(Equal(http.StatusOK))
+		Expect(planned.Body.String()).To(MatchJSON(`{"token":"foo","limit":20}`))
+		Expect(planned.Body.String()).To(MatchJSON(unplanned.Body.String()))
+	})
+
+	It("should allocate less than the unplanned route", func() {
+		planned := testing.AllocsPerRun(100, func() { k.Do(http.MethodGet, "/planned", request) })
+		unplanned := testing.AllocsPerRun(100, func() { k.Do(http.MethodGet, "/unplanned", request) })
+		Expect(planned).To(BeNumerically("<", unplanned))
+	})
+
+	It("bench", Serial, func() {
+		experiment := gmeasure.NewExperiment("gin plan - Benchmark")
+		for _, path := range []string{"/planned", "/unplanned"} {
+			path := path
+			experiment.RecordValue(path+" allocs", testing.AllocsPerRun(100, func() { k.Do(http.MethodGet, path, request) }))
+			experiment.Sample(func(idx int) {
+				experiment.MeasureDuration(path, func() {
+					k.Do(http.MethodGet, path, request)
+				}, gmeasure.Precision(time.Microsecond))
+			}, gmeasure.SamplingConfig{N: 2000, Duration: 10 * time.Second})
+		}
+		AddReportEntry(experiment.Name, experiment)
+	})
+})
+
+// baselineDefaultBinding is the default binding before the plan, which scans the type and decodes on each request
+type baselineDefaultBinding struct {
+	*helper.GinDefaultBinding
+}
+
+func (b *baselineDefaultBinding) Bind(c *gin.Context, obj any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:    b.TagName,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(b.DecodeHooks...),
+		Result:     obj,
+	})
+	if err != nil {
+		return err
+	}
+	var parseStruct func(t reflect.Type) map[string]any
+	parseStruct = func(t reflect.Type) map[string]any {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		dict := make(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := f.Name
+			if tName, ok := f.Tag.Lookup(b.TagName); ok {
+				name = tName
+			}
+			if f.Anonymous {
+				for k, v := range parseStruct(f.Type) {
+					dict[k] = v
+				}
+			}
+			if defaultStr, ok := f.Tag.Lookup(b.Name()); ok {
+				dict[name] = defaultStr
+			}
+		}
+		return dict
+	}
+	dict := parseStruct(reflect.TypeOf(obj))
+	if len(dict) == 0 {
+		return nil
+	}
+	return decoder.Decode(dict)
+}
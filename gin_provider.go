@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// GinProvider returns the handler argument of its type for the request
+type GinProvider func(c *gin.Context) (reflect.Value, error)
+
+var (
+	ginContextType = reflect.TypeOf((*gin.Context)(nil))
+	contextType    = reflect.TypeOf((*context.Context)(nil)).Elem()
+	loggerType     = reflect.TypeOf((*zerolog.Logger)(nil))
+)
+
+// defaultGinProviders provides *gin.Context, context.Context and the *zerolog.Logger of h
+func defaultGinProviders(h *GinHelper) map[reflect.Type]GinProvider {
+	return map[reflect.Type]GinProvider{
+		ginContextType: func(c *gin.Context) (reflect.Value, error) {
+			return reflect.ValueOf(c), nil
+		},
+		contextType: func(c *gin.Context) (reflect.Value, error) {
+			return reflect.ValueOf(c.Request.Context()).Convert(contextType), nil
+		},
+		loggerType: func(c *gin.Context) (reflect.Value, error) {
+			return reflect.ValueOf(h.logger(c)), nil
+		},
+	}
+}
+
+// Provide registers fn as the provider of the handler arguments of type T on h,
+// the error of fn is handled by the ErrorHandler, e.g.
+//
+//	helper.Provide(h, func(c *gin.Context) (*gorm.DB, error) { return db.WithContext(c), nil })
+func Provide[T any](h *GinHelper, fn func(c *gin.Context) (T, error)) {
+	if h.Providers == nil {
+		h.Providers = make(map[reflect.Type]GinProvider)
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	h.Providers[typ] = func(c *gin.Context) (reflect.Value, error) {
+		v, err := fn(c)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		// ValueOf loses the interface type of T and panics on nil
+		return reflect.ValueOf(&v).Elem(), nil
+	}
+}
+
+// logger returns the request logger, or the helper's Logger, or the global logger of zerolog/log
+func (h *GinHelper) logger(c *gin.Context) *zerolog.Logger {
+	if l := GinLogger(c); l.GetLevel() != zerolog.Disabled {
+		return l
+	}
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return &log.Logger
+}
+
+// ginArgument returns the handler argument for the request, the request struct is given by the caller
+type ginArgument struct {
+	provider GinProvider
+	request  bool
+}
+
+// arguments resolves the arguments of the handler type t, each one is provided, or is the request struct pointer
+// if it is the last one. It returns the index of the request argument, -1 if none.
+func (h *GinHelper) arguments(t reflect.Type) ([]ginArgument, int) {
+	args := make([]ginArgument, t.NumIn())
+	request := -1
+	for i := range args {
+		in := t.In(i)
+		if provider, ok := h.Providers[in]; ok {
+			args[i].provider = provider
+			continue
+		}
+		// a struct pointer before the last argument is not the request, e.g. a *gorm.DB without provider
+		if i != len(args)-1 || in.Kind() != reflect.Ptr || in.Elem().Kind() != reflect.Struct {
+			panic(fmt.Sprintf("handler's argument %s is not provided", in))
+		}
+		args[i].request = true
+		request = i
+	}
+	return args, request
+}
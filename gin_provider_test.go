@@ -0,0 +1,117 @@
+package helper_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Providers", Label("gin", "provider"), func() {
+	type Principal struct {
+		Name string
+	}
+
+	type ProviderRequest struct {
+		ID int `uri:"id"`
+	}
+
+	type ProviderResponse struct {
+		ID   int    `json:"id"`
+		Name string `json:"name,omitempty"`
+	}
+
+	var (
+		k   *helpertest.Kit
+		h   *helper.GinHelper
+		r   *helper.GinRouter
+		buf *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		buf = new(bytes.Buffer)
+		k = helpertest.New()
+		k.Engine.Use(func(c *gin.Context) {
+			log := zerolog.New(buf)
+			c.Request = c.Request.WithContext(log.WithContext(c.Request.Context()))
+		})
+		h, r = k.Helper, k.Router
+		helper.Provide(h, func(c *gin.Context) (*Principal, error) {
+			token := c.GetHeader("Authorization")
+			if token == "" {
+				return nil, helper.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+			}
+			return &Principal{Name: token}, nil
+		})
+	})
+
+	It("should inject context.Context instead of *gin.Context", func() {
+		r.GET("/items/:id", func(ctx context.Context, req *ProviderRequest) (*ProviderResponse, error) {
+			Expect(ctx).NotTo(BeNil())
+			return &ProviderResponse{ID: req.ID}, nil
+		})
+
+		w := k.Do(http.MethodGet, "/items/:id", &ProviderRequest{ID: 7})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"id":7}`))
+	})
+
+	It("should inject the logger of the request context", func() {
+		r.GET("/log", func(log *zerolog.Logger) error {
+			log.Info().Msg("hello")
+			return nil
+		})
+
+		w := k.Do(http.MethodGet, "/log", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(buf.String()).To(ContainSubstring(`"message":"hello"`))
+	})
+
+	It("should inject the registered providers in any order", func() {
+		r.GET("/me/:id", func(p *Principal, c *gin.Context, req *ProviderRequest) (*ProviderResponse, error) {
+			Expect(c).NotTo(BeNil())
+			return &ProviderResponse{ID: req.ID, Name: p.Name}, nil
+		})
+
+		w := k.Do(http.MethodGet, "/me/:id", &ProviderRequest{ID: 1}, helpertest.WithHeader("Authorization", "alice"))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"id":1,"name":"alice"}`))
+
+		w = k.Do(http.MethodGet, "/me/:id", &ProviderRequest{ID: 1})
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should provide interface types", func() {
+		type Clock interface{ Now() string }
+		helper.Provide(h, func(c *gin.Context) (Clock, error) {
+			return nil, nil
+		})
+		r.GET("/clock", func(clock Clock) (*ProviderResponse, error) {
+			Expect(clock).To(BeNil())
+			return &ProviderResponse{}, nil
+		})
+
+		w := k.Do(http.MethodGet, "/clock", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should panic on the arguments which are not provided", func() {
+		Expect(func() {
+			r.GET("/int", func(n int) error { return nil })
+		}).To(PanicWith(ContainSubstring("not provided")))
+		Expect(func() {
+			r.GET("/two", func(a *ProviderRequest, b *ProviderResponse) error { return nil })
+		}).To(PanicWith(ContainSubstring("not provided")))
+		// only the last struct pointer is the request
+		Expect(func() {
+			r.GET("/first", func(a *ProviderRequest, c *gin.Context) error { return nil })
+		}).To(PanicWith(ContainSubstring("ProviderRequest is not provided")))
+	})
+})
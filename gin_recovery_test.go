@@ -0,0 +1,120 @@
+package helper_test
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+type RecoveryHookRequest struct{}
+
+func (*RecoveryHookRequest) BeforeBind(c *gin.Context) error {
+	panic("hook panicked")
+}
+
+type RecoveryError struct{}
+
+func (*RecoveryError) Error() string {
+	return "recovery error"
+}
+
+var _ = Describe("Checking Recovery", Label("gin", "recovery"), func() {
+	type RecoveryResponse struct {
+		OK bool `json:"ok"`
+	}
+
+	var (
+		k   *helpertest.Kit
+		r   *helper.GinRouter
+		buf *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		buf = new(bytes.Buffer)
+		// no gin.Recovery, the panics must not reach the server
+		k = helpertest.New(func(h *helper.GinHelper) {
+			log := zerolog.New(buf)
+			h.Logger = &log
+		})
+		r = k.Router
+	})
+
+	It("should route the panic of the handler to the ErrorHandler", func() {
+		r.GET("/panic", func(c *gin.Context) (*RecoveryResponse, error) {
+			panic("boom")
+		})
+
+		w := k.Do(http.MethodGet, "/panic", nil)
+		Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		Expect(w.Body.String()).To(MatchJSON(`{"code":500,"message":"Internal Server Error"}`))
+		Expect(buf.String()).To(ContainSubstring(`"message":"recovered from panic"`))
+		Expect(buf.String()).To(ContainSubstring(`handler panicked: boom`))
+		Expect(buf.String()).To(ContainSubstring(`"route":"/panic"`))
+		Expect(buf.String()).To(ContainSubstring(`"stack":`))
+	})
+
+	It("should log the stack by the configured marshaller", func() {
+		marshaller := zerolog.ErrorStackMarshaler
+		DeferCleanup(func() {
+			zerolog.ErrorStackMarshaler = marshaller
+		})
+		helper.NewZerologHelper().SetErrorStackMarshaller(func(err error) any {
+			return "custom stack"
+		})
+		r.GET("/panic", func(c *gin.Context) (*RecoveryResponse, error) {
+			panic("boom")
+		})
+
+		Expect(k.Do(http.MethodGet, "/panic", nil).Code).To(Equal(http.StatusInternalServerError))
+		Expect(buf.String()).To(ContainSubstring(`"stack":"custom stack"`))
+	})
+
+	It("should keep the panicked error as the cause", func() {
+		var got error
+		r.GET("/error", func(c *gin.Context) error {
+			panic(helper.NewHTTPError(http.StatusConflict, "conflict"))
+		}, func(route *helper.GinRoute) {
+			route.ErrorHandler = func(c *gin.Context, err error) {
+				got = err
+				c.Status(http.StatusConflict)
+			}
+		})
+
+		w := k.Do(http.MethodGet, "/error", nil)
+		Expect(w.Code).To(Equal(http.StatusConflict))
+		var httpErr *helper.HTTPError
+		Expect(errors.As(got, &httpErr)).To(BeTrue())
+	})
+
+	It("should recover the panics of the hooks and the generic handlers", func() {
+		r.GET("/hook", func(c *gin.Context, req *RecoveryHookRequest) error {
+			return nil
+		})
+		helper.GET(r, "/generic", func(c *gin.Context, req *struct{}) (*RecoveryResponse, error) {
+			panic("generic")
+		})
+
+		Expect(k.Do(http.MethodGet, "/hook", nil).Code).To(Equal(http.StatusInternalServerError))
+		Expect(buf.String()).To(ContainSubstring("hook panicked"))
+		Expect(k.Do(http.MethodGet, "/generic", nil).Code).To(Equal(http.StatusInternalServerError))
+		Expect(buf.String()).To(ContainSubstring("handler panicked: generic"))
+	})
+
+	It("should treat the nil pointer of a concrete error type as no error", func() {
+		r.GET("/nil", func(c *gin.Context) (*RecoveryResponse, *RecoveryError) {
+			return &RecoveryResponse{OK: true}, nil
+		})
+
+		w := k.Do(http.MethodGet, "/nil", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"ok":true}`))
+	})
+})
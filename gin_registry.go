@@ -0,0 +1,165 @@
+package helper
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinRouteInfo describes a registered route for the docs, audits and CI checks
+type GinRouteInfo struct {
+	Method              string         `json:"method"`
+	Path                string         `json:"path"`
+	Handler             string         `json:"handler"`
+	Request             *GinTypeInfo   `json:"request,omitempty"`
+	Response            string         `json:"response,omitempty"`
+	Stream              bool           `json:"stream,omitempty"`
+	Bindings            []string       `json:"bindings,omitempty"`
+	Chain               []string       `json:"chain"`
+	Status              int            `json:"status,omitempty"`
+	Offers              []string       `json:"offers,omitempty"`
+	DisableEnvelope     bool           `json:"disable_envelope,omitempty"`
+	SuccessHandler      string         `json:"success_handler,omitempty"`
+	ErrorHandler        string         `json:"error_handler,omitempty"`
+	BindingErrorHandler string         `json:"binding_error_handler,omitempty"`
+	Summary             string         `json:"summary,omitempty"`
+	Description         string         `json:"description,omitempty"`
+	Tags                []string       `json:"tags,omitempty"`
+	Deprecated          bool           `json:"deprecated,omitempty"`
+	Metadata            map[string]any `json:"metadata,omitempty"`
+}
+
+// GinTypeInfo describes the request type and its fields
+type GinTypeInfo struct {
+	Type   string         `json:"type"`
+	Fields []GinFieldInfo `json:"fields,omitempty"`
+}
+
+// GinFieldInfo describes a request field
+//   - Tags: the names of the field in the binding tags, e.g. {"json": "name"}
+//   - Default: the tag default
+//   - Rules: the validation rules, the tag binding
+type GinFieldInfo struct {
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Default string            `json:"default,omitempty"`
+	Rules   string            `json:"rules,omitempty"`
+}
+
+// Routes returns the routes registered through the router and the routers sharing its registry, in registration order
+func (r *GinRouter) Routes() []GinRoute {
+	return r.registry.list()
+}
+
+// RouteInfos returns the descriptions of Routes
+func (r *GinRouter) RouteInfos() []GinRouteInfo {
+	routes := r.registry.list()
+	infos := make([]GinRouteInfo, 0, len(routes))
+	for _, route := range routes {
+		infos = append(infos, r.helper.routeInfo(route))
+	}
+	return infos
+}
+
+// ServeRoutes registers GET {path} on r, which responds RouteInfos as JSON.
+// The middlewares run before it, e.g. to restrict the endpoint to the debug mode or the admins.
+func (r *GinRouter) ServeRoutes(path string, middlewares ...gin.HandlerFunc) *GinRouter {
+	handlers := append(middlewares[:len(middlewares):len(middlewares)], func(c *gin.Context) {
+		c.JSON(http.StatusOK, r.RouteInfos())
+	})
+	r.routes.GET(path, handlers...)
+	return r
+}
+
+func (h *GinHelper) routeInfo(route GinRoute) GinRouteInfo {
+	info := GinRouteInfo{
+		Method:          route.Method,
+		Path:            route.Path,
+		Handler:         route.Handler,
+		Stream:          route.Stream,
+		Bindings:        route.Bindings,
+		Chain:           route.Chain,
+		Status:          route.Status,
+		Offers:          route.Offers,
+		DisableEnvelope: route.DisableEnvelope,
+		Summary:         route.Summary,
+		Description:     route.Description,
+		Tags:            route.Tags,
+		Deprecated:      route.Deprecated,
+		Metadata:        route.Metadata,
+	}
+	if route.Request != nil {
+		info.Request = h.typeInfo(route.Request)
+	}
+	if route.Response != nil {
+		info.Response = route.Response.String()
+	}
+	if route.SuccessHandler != nil {
+		info.SuccessHandler = nameOfFunction(route.SuccessHandler)
+	}
+	if route.ErrorHandler != nil {
+		info.ErrorHandler = nameOfFunction(route.ErrorHandler)
+	}
+	if route.BindingErrorHandler != nil {
+		info.BindingErrorHandler = nameOfFunction(route.BindingErrorHandler)
+	}
+	return info
+}
+
+// typeInfo describes the exported fields of typ with the tags of the helper's bindings
+func (h *GinHelper) typeInfo(typ reflect.Type) *GinTypeInfo {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, b := range h.Bindings {
+		names, ok := ginBindingTags[b.Name()]
+		if !ok {
+			names = []string{b.Name()}
+		}
+		for _, name := range names {
+			if name != "default" && !seen[name] {
+				seen[name] = true
+				tags = append(tags, name)
+			}
+		}
+	}
+
+	info := &GinTypeInfo{Type: typ.String()}
+	for _, f := range reflect.VisibleFields(typ) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		field := GinFieldInfo{
+			Name:    f.Name,
+			Type:    f.Type.String(),
+			Default: f.Tag.Get("default"),
+			Rules:   f.Tag.Get("binding"),
+		}
+		for _, tag := range tags {
+			value, ok := f.Tag.Lookup(tag)
+			if !ok {
+				continue
+			}
+			if field.Tags == nil {
+				field.Tags = make(map[string]string)
+			}
+			field.Tags[tag], _, _ = strings.Cut(value, ",")
+		}
+		info.Fields = append(info.Fields, field)
+	}
+	return info
+}
+
+// ginGroupHandlers returns the middlewares of the group wrapped by routes, nil if it is not a group
+func ginGroupHandlers(routes gin.IRoutes) gin.HandlersChain {
+	switch g := routes.(type) {
+	case *gin.RouterGroup:
+		return g.Handlers
+	case *gin.Engine:
+		return g.Handlers
+	default:
+		return nil
+	}
+}
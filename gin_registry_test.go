@@ -0,0 +1,98 @@
+package helper_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+func RegistryAuth(c *gin.Context) {
+	if c.GetHeader("Authorization") == "" {
+		c.AbortWithStatus(http.StatusUnauthorized)
+	}
+}
+
+var _ = Describe("Checking Registry", Label("gin", "registry"), func() {
+	type RegistryRequest struct {
+		Token string `header:"token" binding:"required"`
+		ID    int    `uri:"id"`
+		Limit int    `form:"limit" default:"10" binding:"max=100"`
+		Name  string `json:"name,omitempty"`
+	}
+
+	type RegistryResponse struct {
+		Name string `json:"name"`
+	}
+
+	var (
+		k *helpertest.Kit
+		r *helper.GinRouter
+	)
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		r = k.Router
+		admin := r.Group("/admin", RegistryAuth)
+		admin.PUT("/users/:id", func(c *gin.Context, req *RegistryRequest) (*RegistryResponse, error) {
+			return nil, nil
+		}, func(route *helper.GinRoute) {
+			route.Status = http.StatusAccepted
+			route.Summary = "Update a user"
+			route.Metadata = map[string]any{"auth": "admin"}
+		})
+		r.GET("/public", func(c *gin.Context) error {
+			return nil
+		})
+	})
+
+	It("should list the registered routes", func() {
+		routes := r.Routes()
+		Expect(routes).To(HaveLen(2))
+		Expect(routes[0].Path).To(Equal("/admin/users/:id"))
+		Expect(routes[0].Bindings).To(Equal([]string{"default", "header", "uri", "query", "form", "json", "msgpack"}))
+		Expect(routes[0].Chain).To(HaveLen(2))
+		Expect(routes[0].Chain[0]).To(HaveSuffix("RegistryAuth"))
+		Expect(routes[0].Metadata).To(HaveKeyWithValue("auth", "admin"))
+		Expect(routes[1].Bindings).To(BeEmpty())
+		Expect(routes[1].Chain).To(HaveLen(1))
+	})
+
+	It("should describe the fields of the request", func() {
+		info := r.RouteInfos()[0]
+		Expect(info.Method).To(Equal(http.MethodPut))
+		Expect(info.Status).To(Equal(http.StatusAccepted))
+		Expect(info.Summary).To(Equal("Update a user"))
+		Expect(info.Response).To(HaveSuffix("RegistryResponse"))
+		Expect(info.Request.Fields).To(Equal([]helper.GinFieldInfo{
+			{Name: "Token", Type: "string", Tags: map[string]string{"header": "token"}, Rules: "required"},
+			{Name: "ID", Type: "int", Tags: map[string]string{"uri": "id"}},
+			{Name: "Limit", Type: "int", Tags: map[string]string{"form": "limit"}, Default: "10", Rules: "max=100"},
+			{Name: "Name", Type: "string", Tags: map[string]string{"json": "name"}},
+		}))
+	})
+
+	It("should serve the registry as JSON", func() {
+		r.ServeRoutes("/debug/routes")
+
+		w := k.Do(http.MethodGet, "/debug/routes", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var infos []helper.GinRouteInfo
+		Expect(json.Unmarshal(w.Body.Bytes(), &infos)).To(Succeed())
+		Expect(infos).To(HaveLen(2))
+
+		// every route but the public ones requires the auth middleware
+		for _, info := range infos {
+			if strings.HasPrefix(info.Path, "/public") {
+				continue
+			}
+			Expect(info.Chain).To(ContainElement(HaveSuffix("RegistryAuth")), info.Path)
+		}
+	})
+})
@@ -0,0 +1,121 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+)
+
+// GinDefaultOffers are the response formats offered by default, in the order of preference.
+// The first one is used when the request has no Accept header.
+var GinDefaultOffers = []string{
+	binding.MIMEJSON,
+}
+
+// GinRenderOffers are all the response formats rendered by the default handlers,
+// to opt in for a route, e.g. route.Offers = helper.GinRenderOffers, or for all routes by GinHelper.Offers
+var GinRenderOffers = []string{
+	binding.MIMEJSON,
+	binding.MIMEXML,
+	binding.MIMEYAML,
+	binding.MIMETOML,
+	binding.MIMEMSGPACK,
+}
+
+// offers returns the response formats of the route of c, or the helper's, nil if neither sets them.
+// The offers of the streaming route are its stream formats, so they are not used here.
+func (h *GinHelper) offers(c *gin.Context) []string {
+	if route, ok := GinRouteFromContext(c); ok && len(route.Offers) > 0 && !route.Stream {
+		return route.Offers
+	}
+	return h.Offers
+}
+
+// negotiate returns the response format accepted by the request, empty if nothing matches.
+// Without offers the first of GinDefaultOffers is used whatever the Accept is.
+func (h *GinHelper) negotiate(c *gin.Context) string {
+	offers := h.offers(c)
+	if len(offers) == 0 {
+		return GinDefaultOffers[0]
+	}
+	return ginNegotiateFormat(c.GetHeader("Accept"), offers)
+}
+
+// ginNegotiateFormat returns the offer with the highest quality in the accept header, the earlier offer on ties,
+// so the first offer is used for */*. It returns the first offer without accept, empty if nothing is acceptable.
+func ginNegotiateFormat(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+	type mediaRange struct {
+		typ, subtype string
+		quality      float64
+	}
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mime, params, _ := strings.Cut(part, ";")
+		typ, subtype, _ := strings.Cut(strings.ToLower(strings.TrimSpace(mime)), "/")
+		if typ == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if f, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = f
+				}
+			}
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, quality: quality})
+	}
+
+	format, best := "", 0.0
+	for _, offer := range offers {
+		typ, subtype, _ := strings.Cut(strings.ToLower(offer), "/")
+		// the quality of the most specific range applies, e.g. application/xml before application/* before */*
+		quality, specificity := 0.0, -1
+		for _, r := range ranges {
+			s := -1
+			switch {
+			case r.typ == typ && r.subtype == subtype:
+				s = 2
+			case r.typ == typ && r.subtype == "*":
+				s = 1
+			case r.typ == "*" && (r.subtype == "*" || r.subtype == ""):
+				s = 0
+			}
+			if s > specificity {
+				quality, specificity = r.quality, s
+			}
+		}
+		if quality > best {
+			format, best = offer, quality
+		}
+	}
+	return format
+}
+
+// render writes obj with status in format
+//   - ProtoBuf requires obj to be a proto.Message, so it can not be used with the envelope
+func (h *GinHelper) render(c *gin.Context, status int, format string, obj any) {
+	switch format {
+	case binding.MIMEXML, binding.MIMEXML2:
+		c.XML(status, obj)
+	case binding.MIMEYAML:
+		c.YAML(status, obj)
+	case binding.MIMETOML:
+		c.TOML(status, obj)
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		c.Render(status, render.MsgPack{Data: obj})
+	case binding.MIMEPROTOBUF:
+		c.ProtoBuf(status, obj)
+	default:
+		c.JSON(status, obj)
+	}
+}
@@ -0,0 +1,124 @@
+package helper_test
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var ErrRenderHookNotFound = errors.New("record not found")
+
+type RenderHookUser struct {
+	ID    int    `json:"id"`
+	Email string `json:"email,omitempty"`
+}
+
+func (u *RenderHookUser) BeforeRender(c *gin.Context) error {
+	switch c.GetHeader("X-Role") {
+	case "admin":
+	case "":
+		return errors.New("role is required")
+	default:
+		u.Email = ""
+	}
+	c.Header("Cache-Control", "no-store")
+	return nil
+}
+
+type RenderHookCreated struct {
+	ID int `json:"id"`
+}
+
+func (r *RenderHookCreated) BeforeRender(c *gin.Context) error {
+	c.Header("Location", "/users/1")
+	c.Status(http.StatusCreated)
+	return nil
+}
+
+type RenderHookRedirect struct{}
+
+func (*RenderHookRedirect) BeforeRender(c *gin.Context) error {
+	c.Redirect(http.StatusFound, "/login")
+	return nil
+}
+
+var _ = Describe("Checking Render Hook", Label("gin", "render"), func() {
+	var k *helpertest.Kit
+
+	BeforeEach(func() {
+		k = helpertest.New(func(h *helper.GinHelper) {
+			h.ErrorInterceptors = []func(c *gin.Context, err error) error{
+				func(c *gin.Context, err error) error {
+					if errors.Is(err, ErrRenderHookNotFound) {
+						return helper.NewHTTPError(http.StatusNotFound, "user not found")
+					}
+					return err
+				},
+				func(c *gin.Context, err error) error {
+					if c.Query("quiet") != "" {
+						c.Status(http.StatusNoContent)
+						return nil
+					}
+					return err
+				},
+			}
+		})
+		r := k.Router
+		r.GET("/users/:id", func(c *gin.Context) (*RenderHookUser, error) {
+			if c.Param("id") == "0" {
+				return nil, ErrRenderHookNotFound
+			}
+			return &RenderHookUser{ID: 1, Email: "alice@example.com"}, nil
+		})
+		r.GET("/created", func(c *gin.Context) (*RenderHookCreated, error) {
+			return &RenderHookCreated{ID: 1}, nil
+		})
+		r.GET("/redirect", func(c *gin.Context) (*RenderHookRedirect, error) {
+			return &RenderHookRedirect{}, nil
+		})
+	})
+
+	It("should mask the response by the role", func() {
+		w := k.Do(http.MethodGet, "/users/1", nil, helpertest.WithHeader("X-Role", "admin"))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Cache-Control")).To(Equal("no-store"))
+		Expect(w.Body.String()).To(MatchJSON(`{"id":1,"email":"alice@example.com"}`))
+
+		w = k.Do(http.MethodGet, "/users/1", nil, helpertest.WithHeader("X-Role", "guest"))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"id":1}`))
+	})
+
+	It("should respond the error of the hook", func() {
+		w := k.Do(http.MethodGet, "/users/1", nil)
+		Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		Expect(w.Header().Get("Cache-Control")).To(BeEmpty())
+	})
+
+	It("should respond the status and the headers set by the hook", func() {
+		w := k.Do(http.MethodGet, "/created", nil)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		Expect(w.Header().Get("Location")).To(Equal("/users/1"))
+		Expect(w.Body.String()).To(MatchJSON(`{"id":1}`))
+
+		w = k.Do(http.MethodGet, "/redirect", nil)
+		Expect(w.Code).To(Equal(http.StatusFound))
+		Expect(w.Header().Get("Location")).To(Equal("/login"))
+	})
+
+	It("should intercept the errors", func() {
+		w := k.Do(http.MethodGet, "/users/0", nil, helpertest.WithHeader("X-Role", "admin"))
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+		Expect(w.Body.String()).To(ContainSubstring("user not found"))
+
+		w = k.Do(http.MethodGet, "/users/0?quiet=1", nil, helpertest.WithHeader("X-Role", "admin"))
+		Expect(w.Code).To(Equal(http.StatusNoContent))
+		Expect(w.Body.Len()).To(BeZero())
+	})
+})
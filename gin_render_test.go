@@ -0,0 +1,128 @@
+package helper_test
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Render", Label("gin", "render"), func() {
+	type RenderResponse struct {
+		Name string `json:"name" yaml:"name" toml:"name" xml:"name"`
+		Age  int    `json:"age" yaml:"age" toml:"age" xml:"age"`
+	}
+
+	var k *helpertest.Kit
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		r := k.Router
+		all := func(route *helper.GinRoute) {
+			route.Offers = helper.GinRenderOffers
+		}
+		r.GET("/user", func(c *gin.Context) (*RenderResponse, error) {
+			return &RenderResponse{Name: "alice", Age: 18}, nil
+		}, all)
+		r.GET("/missing", func(c *gin.Context) error {
+			return helper.NewHTTPError(http.StatusNotFound, "not found")
+		}, all)
+		r.GET("/default", func(c *gin.Context) (*RenderResponse, error) {
+			return &RenderResponse{Name: "carol"}, nil
+		})
+		r.GET("/json", func(c *gin.Context) (*RenderResponse, error) {
+			return &RenderResponse{Name: "bob"}, nil
+		}, func(route *helper.GinRoute) {
+			route.Offers = []string{binding.MIMEJSON}
+		})
+	})
+
+	It("should render JSON without Accept", func() {
+		w := k.Do(http.MethodGet, "/user", nil)
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEJSON))
+		Expect(w.Body.String()).To(MatchJSON(`{"name":"alice","age":18}`))
+	})
+
+	It("should render JSON for browsers", func() {
+		for _, accept := range []string{"*/*", "application/json, text/plain, */*", "text/html, */*;q=0.8"} {
+			w := k.Do(http.MethodGet, "/user", nil, helpertest.WithHeader("Accept", accept))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEJSON))
+		}
+	})
+
+	It("should render only JSON by default", func() {
+		for _, accept := range []string{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", binding.MIMEYAML} {
+			w := k.Do(http.MethodGet, "/default", nil, helpertest.WithHeader("Accept", accept))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEJSON))
+			Expect(w.Body.String()).To(MatchJSON(`{"name":"carol","age":0}`))
+		}
+	})
+
+	It("should negotiate on the quality", func() {
+		w := k.Do(http.MethodGet, "/user", nil, helpertest.WithHeader("Accept", "application/xml;q=0.5, "+binding.MIMEYAML))
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEYAML))
+
+		w = k.Do(http.MethodGet, "/user", nil, helpertest.WithHeader("Accept", "application/*;q=0.5, application/toml;q=0"))
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEJSON))
+	})
+
+	It("should render YAML", func() {
+		w := k.Do(http.MethodGet, "/user", nil, helpertest.WithHeader("Accept", binding.MIMEYAML))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEYAML))
+		var resp RenderResponse
+		Expect(yaml.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp).To(Equal(RenderResponse{Name: "alice", Age: 18}))
+	})
+
+	It("should render XML", func() {
+		w := k.Do(http.MethodGet, "/user", nil, helpertest.WithHeader("Accept", binding.MIMEXML))
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEXML))
+		var resp RenderResponse
+		Expect(xml.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp).To(Equal(RenderResponse{Name: "alice", Age: 18}))
+	})
+
+	It("should render TOML", func() {
+		w := k.Do(http.MethodGet, "/user", nil, helpertest.WithHeader("Accept", binding.MIMETOML))
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMETOML))
+		Expect(w.Body.String()).To(ContainSubstring(`name = 'alice'`))
+	})
+
+	It("should render MsgPack", func() {
+		w := k.Do(http.MethodGet, "/user", nil, helpertest.WithHeader("Accept", binding.MIMEMSGPACK))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEMSGPACK2))
+		Expect(w.Body.Len()).To(BeNumerically(">", 0))
+	})
+
+	It("should render the errors in the negotiated format", func() {
+		w := k.Do(http.MethodGet, "/missing", nil, helpertest.WithHeader("Accept", binding.MIMEYAML))
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+		Expect(w.Body.String()).To(MatchYAML("code: 404\nmessage: not found\n"))
+
+		w = k.Do(http.MethodGet, "/missing", nil, helpertest.WithHeader("Accept", binding.MIMETOML))
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+		Expect(w.Body.String()).To(Equal("code = 404\nmessage = 'not found'\n"))
+
+		w = k.Do(http.MethodGet, "/missing", nil, helpertest.WithHeader("Accept", binding.MIMEXML))
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+		Expect(w.Body.String()).To(Equal("<error><code>404</code><message>not found</message></error>"))
+	})
+
+	It("should respond 406 if no offered format is acceptable", func() {
+		w := k.Do(http.MethodGet, "/json", nil, helpertest.WithHeader("Accept", binding.MIMEYAML))
+		Expect(w.Code).To(Equal(http.StatusNotAcceptable))
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix(binding.MIMEJSON))
+		Expect(w.Body.String()).To(MatchJSON(`{"code":406,"message":"Not Acceptable"}`))
+	})
+})
@@ -0,0 +1,186 @@
+package helper_test
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Route Options", Label("gin", "route"), func() {
+	type CreateItemRequest struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	type Item struct {
+		Name string `json:"name"`
+	}
+
+	var (
+		k *helpertest.Kit
+		r *helper.GinRouter
+	)
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		r = k.Router
+	})
+
+	It("should respond the route status", func() {
+		r.POST("/items", func(c *gin.Context, req *CreateItemRequest) (*Item, error) {
+			return &Item{Name: req.Name}, nil
+		}, func(route *helper.GinRoute) {
+			route.Status = http.StatusCreated
+		})
+		helper.POST(r, "/jobs", func(c *gin.Context, req *struct{}) (*Item, error) {
+			return &Item{Name: "job"}, nil
+		}, func(route *helper.GinRoute) {
+			route.Status = http.StatusAccepted
+		})
+		r.DELETE("/items/:id", func(c *gin.Context) error {
+			return nil
+		}, func(route *helper.GinRoute) {
+			route.Status = http.StatusNoContent
+		})
+		r.PUT("/items/:id", func(c *gin.Context) (*Item, error) {
+			return &Item{Name: "ignored"}, nil
+		}, func(route *helper.GinRoute) {
+			route.Status = http.StatusNoContent
+		})
+
+		w := k.Do(http.MethodPost, "/items", &CreateItemRequest{Name: "book"})
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		Expect(w.Body.String()).To(MatchJSON(`{"name":"book"}`))
+
+		w = k.Do(http.MethodPost, "/jobs", nil)
+		Expect(w.Code).To(Equal(http.StatusAccepted))
+		Expect(w.Body.String()).To(MatchJSON(`{"name":"job"}`))
+
+		w = k.Do(http.MethodDelete, "/items/1", nil)
+		Expect(w.Code).To(Equal(http.StatusNoContent))
+		Expect(w.Body.String()).To(BeEmpty())
+
+		w = k.Do(http.MethodPut, "/items/1", nil)
+		Expect(w.Code).To(Equal(http.StatusNoContent))
+		Expect(w.Body.String()).To(BeEmpty())
+
+		w = k.Do(http.MethodPost, "/items", &CreateItemRequest{})
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should run the route middlewares only for the route", func() {
+		var calls []string
+		r.Use(func(c *gin.Context) {
+			calls = append(calls, "group")
+		})
+		r.GET("/private", func(c *gin.Context) (*Item, error) {
+			calls = append(calls, "handler")
+			return &Item{Name: "private"}, nil
+		}, func(route *helper.GinRoute) {
+			route.Middlewares = []gin.HandlerFunc{func(c *gin.Context) {
+				calls = append(calls, "route")
+				route, ok := helper.GinRouteFromContext(c)
+				Expect(ok).To(BeTrue())
+				Expect(route.Path).To(Equal("/private"))
+				if c.Query("token") == "" {
+					c.AbortWithStatus(http.StatusUnauthorized)
+				}
+			}}
+		})
+		r.GET("/public", func(c *gin.Context) (*Item, error) {
+			calls = append(calls, "handler")
+			return &Item{Name: "public"}, nil
+		})
+
+		w := k.Do(http.MethodGet, "/private", nil)
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		Expect(calls).To(Equal([]string{"group", "route"}))
+
+		calls = nil
+		w = k.Do(http.MethodGet, "/private?token=t", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(calls).To(Equal([]string{"group", "route", "handler"}))
+
+		calls = nil
+		w = k.Do(http.MethodGet, "/public", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(calls).To(Equal([]string{"group", "handler"}))
+	})
+
+	It("should override the handlers of the helper", func() {
+		override := func(route *helper.GinRoute) {
+			route.SuccessHandler = func(c *gin.Context, resp any) {
+				c.String(http.StatusOK, "success:%s", resp.(*Item).Name)
+			}
+			route.ErrorHandler = func(c *gin.Context, err error) {
+				c.String(http.StatusTeapot, "error:%s", err)
+			}
+			route.BindingErrorHandler = func(c *gin.Context, err error) {
+				c.String(http.StatusUnprocessableEntity, "binding")
+			}
+		}
+		r.POST("/items", func(c *gin.Context, req *CreateItemRequest) (*Item, error) {
+			if req.Name == "fail" {
+				return nil, helper.NewHTTPError(http.StatusConflict, "conflict")
+			}
+			return &Item{Name: req.Name}, nil
+		}, override)
+		helper.POST(r, "/generic", func(c *gin.Context, req *CreateItemRequest) (*Item, error) {
+			return &Item{Name: req.Name}, nil
+		}, override)
+		r.POST("/default", func(c *gin.Context, req *CreateItemRequest) (*Item, error) {
+			return &Item{Name: req.Name}, nil
+		})
+
+		w := k.Do(http.MethodPost, "/items", &CreateItemRequest{Name: "book"})
+		Expect(w.Body.String()).To(Equal("success:book"))
+
+		w = k.Do(http.MethodPost, "/items", &CreateItemRequest{Name: "fail"})
+		Expect(w.Code).To(Equal(http.StatusTeapot))
+		Expect(w.Body.String()).To(HavePrefix("error:"))
+
+		w = k.Do(http.MethodPost, "/items", &CreateItemRequest{})
+		Expect(w.Code).To(Equal(http.StatusUnprocessableEntity))
+		Expect(w.Body.String()).To(Equal("binding"))
+
+		w = k.Do(http.MethodPost, "/generic", &CreateItemRequest{Name: "pen"})
+		Expect(w.Body.String()).To(Equal("success:pen"))
+
+		w = k.Do(http.MethodPost, "/default", &CreateItemRequest{Name: "book"})
+		Expect(w.Body.String()).To(MatchJSON(`{"name":"book"}`))
+	})
+
+	It("should describe the route metadata in the OpenAPI document", func() {
+		r.POST("/items", func(c *gin.Context, req *CreateItemRequest) (*Item, error) {
+			return &Item{Name: req.Name}, nil
+		}, func(route *helper.GinRoute) {
+			route.Status = http.StatusCreated
+			route.Summary = "Create an item"
+			route.Description = "Creates an item by name"
+			route.Tags = []string{"items"}
+		})
+		r.DELETE("/items/:id", func(c *gin.Context) (*Item, error) {
+			return nil, nil
+		}, func(route *helper.GinRoute) {
+			route.Status = http.StatusNoContent
+			route.Deprecated = true
+		})
+
+		doc := r.OpenAPI()
+		post := doc.Paths["/items"]["post"]
+		Expect(post.Summary).To(Equal("Create an item"))
+		Expect(post.Description).To(Equal("Creates an item by name"))
+		Expect(post.Tags).To(Equal([]string{"items"}))
+		Expect(post.Responses).To(HaveKey("201"))
+		Expect(post.Responses).NotTo(HaveKey("200"))
+		Expect(post.Responses["201"].Content["application/json"].Schema.Ref).To(Equal("#/components/schemas/Item"))
+
+		del := doc.Paths["/items/{id}"]["delete"]
+		Expect(del.Deprecated).To(BeTrue())
+		Expect(del.Responses["204"].Content).To(BeEmpty())
+	})
+})
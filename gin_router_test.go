@@ -0,0 +1,99 @@
+package helper_test
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Router", Label("gin", "router"), func() {
+	type ItemRequest struct {
+		ID   string `uri:"id" binding:"required"`
+		Name string `json:"name"`
+	}
+
+	type PathRequest struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	type ItemResponse struct {
+		Method string `json:"method"`
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Group  string `json:"group"`
+	}
+
+	var k *helpertest.Kit
+
+	handler := func(c *gin.Context, req *ItemRequest) (*ItemResponse, error) {
+		return &ItemResponse{
+			Method: c.Request.Method,
+			ID:     req.ID,
+			Name:   req.Name,
+			Group:  c.GetString("group"),
+		}, nil
+	}
+
+	pathHandler := func(c *gin.Context, req *PathRequest) (*ItemResponse, error) {
+		return handler(c, &ItemRequest{ID: req.ID})
+	}
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		r := k.Router
+		r.PUT("/items/:id", handler).
+			PATCH("/items/:id", handler).
+			DELETE("/items/:id", handler).
+			Any("/any/:id", pathHandler)
+		r.Group("/v2", func(c *gin.Context) {
+			c.Set("group", "v2")
+			c.Next()
+		}).
+			GET("/items/:id", pathHandler).
+			Group("/admin").
+			OPTIONS("/items/:id", pathHandler)
+	})
+
+	When("method is one of [ PUT, PATCH, DELETE ]", func() {
+		It("should bind and return success", func() {
+			for _, method := range []string{http.MethodPut, http.MethodPatch, http.MethodDelete} {
+				w := k.Do(method, "/items/:id", &ItemRequest{ID: "1", Name: "foo"})
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"method":"` + method + `","id":"1","name":"foo","group":""}`))
+			}
+		})
+	})
+
+	When("handler is registered with Any", func() {
+		It("should accept every method", func() {
+			for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodTrace} {
+				w := k.Do(method, "/any/:id", &PathRequest{ID: "1"})
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring(`"method":"` + method + `"`))
+			}
+		})
+	})
+
+	When("handler is registered on a group", func() {
+		It("should apply the group prefix and middlewares", func() {
+			w := k.Do(http.MethodGet, "/v2/items/:id", &PathRequest{ID: "1"})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`"group":"v2"`))
+		})
+
+		It("should apply the parent group to nested groups", func() {
+			w := k.Do(http.MethodOptions, "/v2/admin/items/:id", &PathRequest{ID: "1"})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`"group":"v2"`))
+		})
+
+		It("should not register the route outside the group", func() {
+			w := k.Do(http.MethodGet, "/items/:id", &PathRequest{ID: "1"})
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,201 @@
+package helper
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	MIMEEventStream = "text/event-stream"
+	MIMENDJSON      = "application/x-ndjson"
+)
+
+// GinStreamOffers are the stream formats offered by default, the first one is used when the request has no Accept header
+var GinStreamOffers = []string{MIMEEventStream, MIMENDJSON}
+
+// GinStreamFunc is the type-safe streaming handler registered by Stream
+type GinStreamFunc[Req, Item any] func(c *gin.Context, req *Req) (<-chan Item, error)
+
+// Stream registers fn for method and path on r, the items of the returned channel are streamed
+// as Server-Sent Events or newline-delimited JSON
+//   - the producer should stop sending and close the channel when c.Request.Context() is done
+func Stream[Req, Item any](r *GinRouter, method string, path string, fn GinStreamFunc[Req, Item], options ...func(*GinRoute)) *GinRouter {
+	reqT := reflect.TypeOf((*Req)(nil)).Elem()
+	if reqT.Kind() != reflect.Struct {
+		panic("handler's request must be a struct")
+	}
+	plan := newGinRequestPlan(r.helper, reqT)
+
+	route := GinRoute{
+		Method:   method,
+		Path:     joinPaths(r.BasePath(), path),
+		Handler:  nameOfFunction(fn),
+		Bindings: plan.bindings(),
+		Response: reflect.TypeOf((*Item)(nil)).Elem(),
+		Stream:   true,
+	}
+	if reqT.NumField() > 0 {
+		route.Request = reqT
+	}
+	for _, opt := range options {
+		opt(&route)
+	}
+
+	return r.handle(path, route, func(c *gin.Context) {
+		req := new(Req)
+		if err := plan.bind(c, req); err != nil {
+			r.onBindingError(c, &route, err)
+			return
+		}
+		items, err := fn(c, req)
+		if err != nil {
+			r.onError(c, &route, err)
+			return
+		}
+		if items != nil {
+			r.helper.stream(c, reflect.ValueOf(items))
+		}
+	})
+}
+
+var boolType = reflect.TypeOf(true)
+
+// ginStreamItem returns the item type of a streaming handler result,
+// which is a receivable channel or an iterator func(yield func(Item) bool)
+func ginStreamItem(typ reflect.Type) (reflect.Type, bool) {
+	switch typ.Kind() {
+	case reflect.Chan:
+		if typ.ChanDir()&reflect.RecvDir == 0 {
+			return nil, false
+		}
+		return typ.Elem(), true
+	case reflect.Func:
+		if typ.NumIn() != 1 || typ.NumOut() != 0 {
+			return nil, false
+		}
+		yield := typ.In(0)
+		if yield.Kind() != reflect.Func || yield.NumIn() != 1 || yield.NumOut() != 1 || yield.Out(0) != boolType {
+			return nil, false
+		}
+		return yield.In(0), true
+	default:
+		return nil, false
+	}
+}
+
+// stream writes the items of result, a channel or an iterator, in the format negotiated on Accept.
+// It flushes after each item, writes an SSE heartbeat every StreamHeartbeat and returns when
+// the items are drained or the client disconnects.
+func (h *GinHelper) stream(c *gin.Context, result reflect.Value) {
+	offers := GinStreamOffers
+	if route, ok := GinRouteFromContext(c); ok && len(route.Offers) > 0 {
+		offers = route.Offers
+	}
+	format := ginNegotiateFormat(c.GetHeader("Accept"), offers)
+	if format == "" {
+		h.abortWithHTTPError(c, NewHTTPError(http.StatusNotAcceptable, http.StatusText(http.StatusNotAcceptable)))
+		return
+	}
+
+	done := c.Request.Context().Done()
+	items := result
+	if result.Kind() == reflect.Func {
+		stop := make(chan struct{})
+		var wait func() any
+		items, wait = ginIterate(result, stop)
+		// the iterator returns before c goes back to the pool, its panic is raised again for the Recovery
+		defer func() {
+			close(stop)
+			if p := wait(); p != nil {
+				panic(p)
+			}
+		}()
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", format)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	if format == MIMEEventStream {
+		header.Set("Connection", "keep-alive")
+	}
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+		{Dir: reflect.SelectRecv, Chan: items},
+	}
+	// the heartbeat is an SSE comment, NDJSON has no line which the parsers ignore
+	if h.StreamHeartbeat > 0 && format == MIMEEventStream {
+		ticker := time.NewTicker(h.StreamHeartbeat)
+		defer ticker.Stop()
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ticker.C)})
+	}
+	for {
+		chosen, item, ok := reflect.Select(cases)
+		var err error
+		switch chosen {
+		case 0:
+			return
+		case 1:
+			if !ok {
+				return
+			}
+			err = writeGinStreamItem(c.Writer, format, item.Interface())
+		default:
+			_, err = io.WriteString(c.Writer, ":\n\n")
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// ginIterate runs the iterator in a goroutine and returns the channel of its items, yield returns false once stop is closed.
+// wait blocks until the iterator returns and returns its panic, the iterator must return once yield returns false.
+func ginIterate(iterator reflect.Value, stop <-chan struct{}) (items reflect.Value, wait func() any) {
+	yieldT := iterator.Type().In(0)
+	items = reflect.MakeChan(reflect.ChanOf(reflect.BothDir, yieldT.In(0)), 0)
+	yield := reflect.MakeFunc(yieldT, func(args []reflect.Value) []reflect.Value {
+		chosen, _, _ := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(stop)},
+			{Dir: reflect.SelectSend, Chan: items, Send: args[0]},
+		})
+		return []reflect.Value{reflect.ValueOf(chosen == 1)}
+	})
+	exited := make(chan any, 1)
+	go func() {
+		defer items.Close()
+		defer func() {
+			exited <- recover()
+		}()
+		iterator.Call([]reflect.Value{yield})
+	}()
+	return items, func() any {
+		return <-exited
+	}
+}
+
+// writeGinStreamItem writes item as an SSE event or a JSON line, sse.Event is written as-is
+func writeGinStreamItem(w io.Writer, format string, item any) error {
+	if format == MIMEEventStream {
+		event, ok := item.(sse.Event)
+		if !ok {
+			event = sse.Event{Data: item}
+		}
+		return sse.Encode(w, event)
+	}
+	if event, ok := item.(sse.Event); ok {
+		item = event.Data
+	}
+	return json.NewEncoder(w).Encode(item)
+}
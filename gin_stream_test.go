@@ -0,0 +1,152 @@
+package helper_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Stream", Label("gin", "stream"), func() {
+	type Progress struct {
+		Percent int `json:"percent"`
+	}
+
+	type TailRequest struct {
+		Lines int `form:"lines" default:"3"`
+	}
+
+	var (
+		k       *helpertest.Kit
+		h       *helper.GinHelper
+		stopped chan struct{}
+		ctx     context.Context
+		cancel  context.CancelFunc
+		logs    *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		logs = new(bytes.Buffer)
+		k = helpertest.New(func(h *helper.GinHelper) {
+			log := zerolog.New(logs)
+			h.Logger = &log
+		})
+		h = k.Helper
+		stopped = make(chan struct{})
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		r := k.Router
+		r.GET("/progress", func(c *gin.Context) (<-chan Progress, error) {
+			ch := make(chan Progress)
+			go func() {
+				defer close(ch)
+				for _, p := range []int{50, 100} {
+					ch <- Progress{Percent: p}
+				}
+			}()
+			return ch, nil
+		})
+		r.GET("/tail", func(c *gin.Context, req *TailRequest) (func(yield func(string) bool), error) {
+			return func(yield func(string) bool) {
+				defer close(stopped)
+				for i := 0; i < req.Lines; i++ {
+					if !yield("line") {
+						return
+					}
+				}
+			}, nil
+		})
+		r.GET("/forever", func(c *gin.Context) (func(yield func(int) bool), error) {
+			return func(yield func(int) bool) {
+				defer close(stopped)
+				for i := 0; yield(i); i++ {
+					if i == 2 {
+						cancel()
+					}
+				}
+			}, nil
+		})
+		r.GET("/panic", func(c *gin.Context) (func(yield func(int) bool), error) {
+			return func(yield func(int) bool) {
+				yield(1)
+				panic("boom")
+			}, nil
+		})
+		r.GET("/slow", func(c *gin.Context) (<-chan Progress, error) {
+			ch := make(chan Progress)
+			go func() {
+				defer close(ch)
+				time.Sleep(50 * time.Millisecond)
+				ch <- Progress{Percent: 100}
+			}()
+			return ch, nil
+		})
+		helper.Stream(r, http.MethodGet, "/events", func(c *gin.Context, req *struct{}) (<-chan sse.Event, error) {
+			ch := make(chan sse.Event, 1)
+			ch <- sse.Event{Id: "1", Event: "done", Data: "ok"}
+			close(ch)
+			return ch, nil
+		})
+	})
+
+	It("should write the channel items as Server-Sent Events", func() {
+		w := k.Do(http.MethodGet, "/progress", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(Equal(helper.MIMEEventStream))
+		Expect(w.Body.String()).To(Equal("data:{\"percent\":50}\n\ndata:{\"percent\":100}\n\n"))
+		Expect(w.Flushed).To(BeTrue())
+	})
+
+	It("should write the iterator items as newline-delimited JSON", func() {
+		w := k.Do(http.MethodGet, "/tail", &TailRequest{Lines: 2}, helpertest.WithHeader("Accept", helper.MIMENDJSON))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(Equal(helper.MIMENDJSON))
+		Expect(w.Body.String()).To(Equal("\"line\"\n\"line\"\n"))
+		Eventually(stopped).Should(BeClosed())
+	})
+
+	It("should write sse.Event as-is", func() {
+		w := k.Do(http.MethodGet, "/events", nil, helpertest.WithHeader("Accept", helper.MIMEEventStream))
+		Expect(w.Body.String()).To(Equal("id:1\nevent:done\ndata:ok\n\n"))
+	})
+
+	It("should stop the iterator when the client disconnects", func() {
+		w := k.Do(http.MethodGet, "/forever", nil, helpertest.WithContext(ctx), helpertest.WithHeader("Accept", helper.MIMENDJSON))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		// the iterator has returned when the handler returns
+		Expect(stopped).To(BeClosed())
+	})
+
+	It("should raise the panic of the iterator in the handler", func() {
+		w := k.Do(http.MethodGet, "/panic", nil, helpertest.WithHeader("Accept", helper.MIMENDJSON))
+		// the stream has started, so only the log has the error
+		Expect(w.Body.String()).To(Equal("1\n"))
+		Expect(logs.String()).To(ContainSubstring("handler panicked: boom"))
+	})
+
+	It("should write the heartbeats", func() {
+		h.StreamHeartbeat = 10 * time.Millisecond
+		w := k.Do(http.MethodGet, "/slow", nil)
+		Expect(w.Body.String()).To(HavePrefix(":\n\n"))
+		Expect(w.Body.String()).To(HaveSuffix("data:{\"percent\":100}\n\n"))
+
+		// NDJSON has no heartbeat
+		w = k.Do(http.MethodGet, "/slow", nil, helpertest.WithHeader("Accept", helper.MIMENDJSON))
+		Expect(w.Body.String()).To(Equal("{\"percent\":100}\n"))
+	})
+
+	It("should respond 406 if no stream format is acceptable", func() {
+		w := k.Do(http.MethodGet, "/progress", nil, helpertest.WithHeader("Accept", "application/json"))
+		Expect(w.Code).To(Equal(http.StatusNotAcceptable))
+		Expect(w.Body.String()).To(MatchJSON(`{"code":406,"message":"Not Acceptable"}`))
+	})
+})
@@ -0,0 +1,272 @@
+package helper
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	validator "github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	jaTranslations "github.com/go-playground/validator/v10/translations/ja"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+// GinContextValidator is implemented by validators which validate with the request,
+// e.g. to translate the messages to the locale of the client.
+type GinContextValidator interface {
+	ValidateStructContext(c *gin.Context, obj any) error
+}
+
+// GinFieldTranslator is implemented by validators which translate the field errors returned by the bindings,
+// e.g. the failed constraints of GinFileBinding, to the locale of the request
+type GinFieldTranslator interface {
+	TranslateField(c *gin.Context, fe *FieldError)
+}
+
+// GinValidatorLocale is a locale the validator can translate the messages to
+type GinValidatorLocale struct {
+	Translator locales.Translator
+	Register   func(v *validator.Validate, trans ut.Translator) error
+}
+
+type GinValidator struct {
+	Validate *validator.Validate
+	// Translator is the fallback locale, it is used when no locale of the request matches
+	Translator         locales.Translator
+	TranslatorRegister func(v *validator.Validate, trans ut.Translator) error
+	// Locales are the other locales which can be picked per request
+	Locales []GinValidatorLocale
+	// LocaleQuery is the query parameter which picks the locale before Accept-Language, empty to disable
+	LocaleQuery string
+	Verbose     bool
+	// FieldNameTags are the tags which give the field name in ValidationError, the first one found wins
+	FieldNameTags []string
+	utTranslator  *ut.UniversalTranslator
+}
+
+var (
+	_ binding.StructValidator = (*GinValidator)(nil)
+	_ GinContextValidator     = (*GinValidator)(nil)
+	_ GinFieldTranslator      = (*GinValidator)(nil)
+)
+
+func NewGinValidator(options ...func(*GinValidator)) *GinValidator {
+	v := validator.New()
+	v.SetTagName("binding")
+
+	gv := &GinValidator{
+		Validate:           v,
+		Translator:         zh.New(),
+		TranslatorRegister: zhTranslations.RegisterDefaultTranslations,
+		Locales: []GinValidatorLocale{
+			{Translator: en.New(), Register: enTranslations.RegisterDefaultTranslations},
+			{Translator: ja.New(), Register: jaTranslations.RegisterDefaultTranslations},
+		},
+		LocaleQuery:   "lang",
+		Verbose:       false,
+		FieldNameTags: []string{"json", "form", "uri", "header", "cookie", "file"},
+	}
+
+	for _, opt := range options {
+		opt(gv)
+	}
+
+	gv.Validate.RegisterTagNameFunc(gv.fieldName)
+
+	gv.utTranslator = ut.New(gv.Translator)
+
+	err := gv.TranslatorRegister(gv.Validate, gv.utTranslator.GetFallback())
+	if err != nil {
+		panic(err)
+	}
+	if err := registerGinFileTranslations(gv.utTranslator.GetFallback()); err != nil {
+		panic(err)
+	}
+
+	for _, l := range gv.Locales {
+		if l.Translator.Locale() == gv.Translator.Locale() {
+			continue
+		}
+		if err := gv.utTranslator.AddTranslator(l.Translator, false); err != nil {
+			panic(err)
+		}
+		trans, _ := gv.utTranslator.GetTranslator(l.Translator.Locale())
+		if err := l.Register(gv.Validate, trans); err != nil {
+			panic(err)
+		}
+		if err := registerGinFileTranslations(trans); err != nil {
+			panic(err)
+		}
+	}
+
+	return gv
+}
+
+// fieldName returns the external name of the field, or "" to use the struct field name
+func (v *GinValidator) fieldName(f reflect.StructField) string {
+	for _, tag := range v.FieldNameTags {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
+
+// FindTranslator picks the translator of the request
+//   - the LocaleQuery parameter
+//   - the Accept-Language header, in order of quality
+//   - the base language of each of them, e.g. zh for zh-CN
+//   - the fallback Translator
+func (v *GinValidator) FindTranslator(c *gin.Context) ut.Translator {
+	var candidates []string
+	if v.LocaleQuery != "" {
+		if lang := c.Query(v.LocaleQuery); lang != "" {
+			candidates = append(candidates, lang)
+		}
+	}
+	candidates = append(candidates, parseAcceptLanguage(c.GetHeader("Accept-Language"))...)
+	for _, locale := range candidates {
+		locale = strings.ReplaceAll(locale, "-", "_")
+		if trans, found := v.utTranslator.FindTranslator(locale); found {
+			return trans
+		}
+		if base, _, ok := strings.Cut(locale, "_"); ok {
+			if trans, found := v.utTranslator.FindTranslator(base); found {
+				return trans
+			}
+		}
+	}
+	return v.utTranslator.GetFallback()
+}
+
+// TranslateField sets the message of fe in the locale of the request, which falls back to the fallback locale
+// and to the rule if neither has a translation of the rule
+func (v *GinValidator) TranslateField(c *gin.Context, fe *FieldError) {
+	for _, trans := range []ut.Translator{v.FindTranslator(c), v.utTranslator.GetFallback()} {
+		if msg, err := trans.T(fe.Rule, fe.Field, fe.Param); err == nil {
+			fe.Message = msg
+			return
+		}
+	}
+	fe.Message = fe.Field + " " + fe.Rule + " " + fe.Param
+}
+
+// parseAcceptLanguage returns the languages of the header in order of quality
+func parseAcceptLanguage(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+	var langs []language
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = f
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		langs = append(langs, language{tag: tag, quality: quality})
+	}
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].quality > langs[j].quality
+	})
+	res := make([]string, 0, len(langs))
+	for _, l := range langs {
+		res = append(res, l.tag)
+	}
+	return res
+}
+
+func (v *GinValidator) ValidateStruct(obj any) error {
+	return v.validateStruct(obj, v.utTranslator.GetFallback())
+}
+
+// ValidateStructContext validates obj and translates the messages to the locale of the request
+func (v *GinValidator) ValidateStructContext(c *gin.Context, obj any) error {
+	return v.validateStruct(obj, v.FindTranslator(c))
+}
+
+func (v *GinValidator) validateStruct(obj any, trans ut.Translator) error {
+	val := reflect.ValueOf(obj)
+	typ := val.Type()
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	err := v.Validate.Struct(obj)
+	if err != nil {
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			return err
+		}
+		verr := &ValidationError{
+			Fields:  make([]FieldError, 0, len(errs)),
+			verbose: v.Verbose,
+		}
+		// errs is in struct field order
+		for _, fe := range errs {
+			verr.Fields = append(verr.Fields, FieldError{
+				Field:   fieldPath(typ, fe),
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: fe.Translate(trans),
+			})
+		}
+		return verr
+	}
+
+	return nil
+}
+
+// fieldPath returns the path of the field of fe by the names of the FieldNameTags, without the root struct
+// and the embedded structs, e.g. home.city for Home.City
+func fieldPath(typ reflect.Type, fe validator.FieldError) string {
+	names := strings.Split(fe.Namespace(), ".")
+	fields := strings.Split(fe.StructNamespace(), ".")
+	if len(names) != len(fields) || len(names) < 2 {
+		return fe.Field()
+	}
+	path := make([]string, 0, len(names)-1)
+	for i := 1; i < len(names); i++ {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() == reflect.Struct {
+			name, _, _ := strings.Cut(fields[i], "[")
+			if f, ok := typ.FieldByName(name); ok {
+				typ = f.Type
+				if f.Anonymous {
+					continue
+				}
+			}
+		}
+		path = append(path, names[i])
+	}
+	return strings.Join(path, ".")
+}
+
+func (v *GinValidator) Engine() any {
+	return v.Validate
+}
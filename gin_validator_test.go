@@ -0,0 +1,133 @@
+package helper_test
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Validator", Label("gin", "validator"), func() {
+	type SignUpRequest struct {
+		Invite   string `uri:"invite" binding:"len=6"`
+		Username string `json:"username" binding:"required"`
+		Password string `json:"password,omitempty" binding:"min=8"`
+		Age      int    `form:"age" binding:"gte=18"`
+		Nickname string `binding:"max=3"`
+	}
+
+	It("should return one field error per failed field in struct field order", func() {
+		err := helper.NewGinValidator().ValidateStruct(&SignUpRequest{
+			Invite:   "abc",
+			Password: "123",
+			Age:      10,
+			Nickname: "alice",
+		})
+		var verr *helper.ValidationError
+		Expect(errors.As(err, &verr)).To(BeTrue())
+		Expect(verr.Fields).To(HaveLen(5))
+		fields := make([]string, 0, len(verr.Fields))
+		for _, f := range verr.Fields {
+			fields = append(fields, f.Field+":"+f.Rule+"="+f.Param)
+			Expect(f.Message).NotTo(BeEmpty())
+		}
+		Expect(fields).To(Equal([]string{
+			"invite:len=6",
+			"username:required=",
+			"password:min=8",
+			"age:gte=18",
+			"Nickname:max=3",
+		}))
+		Expect(verr.Fields[1].Message).To(Equal("username为必填字段"))
+	})
+
+	It("should name the nested fields by their path", func() {
+		type Address struct {
+			City string `json:"city" binding:"required"`
+		}
+		type Audit struct {
+			Reason string `json:"reason" binding:"required"`
+		}
+		type ProfileRequest struct {
+			Audit
+			Home  Address   `json:"home"`
+			Work  *Address  `json:"work"`
+			Other []Address `json:"other" binding:"dive"`
+		}
+		err := helper.NewGinValidator().ValidateStruct(&ProfileRequest{Work: &Address{}, Other: []Address{{}}})
+		var verr *helper.ValidationError
+		Expect(errors.As(err, &verr)).To(BeTrue())
+		fields := make([]string, 0, len(verr.Fields))
+		for _, f := range verr.Fields {
+			fields = append(fields, f.Field)
+		}
+		Expect(fields).To(Equal([]string{"reason", "home.city", "work.city", "other[0].city"}))
+	})
+
+	It("should prefix the messages with the field names in verbose mode", func() {
+		err := helper.NewGinValidator(func(v *helper.GinValidator) {
+			v.Verbose = true
+		}).ValidateStruct(&SignUpRequest{Invite: "abcdef", Password: "12345678", Age: 18})
+		Expect(err).To(MatchError("[username=username为必填字段]"))
+	})
+
+	Context("and the request asks for a locale", func() {
+		var k *helpertest.Kit
+
+		BeforeEach(func() {
+			k = helpertest.New()
+			k.Router.POST("/signup/:invite", func(c *gin.Context, req *SignUpRequest) error {
+				return nil
+			})
+		})
+
+		message := func(acceptLanguage string, options ...func(*http.Request)) string {
+			options = append(options, helpertest.WithHeader("Accept-Language", acceptLanguage))
+			w := k.Do(http.MethodPost, "/signup/:invite", &SignUpRequest{Invite: "abcdef", Password: "12345678", Age: 18}, options...)
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			fields, err := w.FieldErrors()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fields).To(HaveLen(1))
+			return fields[0].Message
+		}
+
+		It("should translate with the best Accept-Language", func() {
+			Expect(message("fr;q=0.9, en;q=0.8, ja;q=0.1")).To(Equal("username is a required field"))
+		})
+
+		It("should fall back to the base language", func() {
+			Expect(message("ja-JP")).To(Equal("usernameは必須フィールドです"))
+		})
+
+		It("should prefer the query parameter", func() {
+			Expect(message("ja", func(r *http.Request) {
+				r.URL.RawQuery += "&lang=en"
+			})).To(Equal("username is a required field"))
+		})
+
+		It("should fall back to the default locale", func() {
+			Expect(message("fr")).To(Equal("username为必填字段"))
+		})
+	})
+
+	It("should render the field errors with the default BindingErrorHandler", func() {
+		k := helpertest.New()
+		k.Router.POST("/signup/:invite", func(c *gin.Context, req *SignUpRequest) error {
+			return nil
+		})
+		w := k.Do(http.MethodPost, "/signup/:invite", &SignUpRequest{Invite: "abcdef", Password: "12345678", Age: 18})
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(MatchJSON(`{
+			"code": 400,
+			"message": "Bad Request",
+			"details": [
+				{"field": "username", "rule": "required", "message": "username为必填字段"}
+			]
+		}`))
+	})
+})
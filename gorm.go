@@ -23,13 +23,26 @@ type GormHelper struct {
 	Config *gorm.Config
 }
 
+// NewGormHelper returns an independent helper with its own gorm.Config, the options are applied after the defaults
+func NewGormHelper(options ...func(*GormHelper)) *GormHelper {
+	h := &GormHelper{
+		Config: &gorm.Config{
+			Logger: NewGormZerologLogger(),
+		},
+	}
+
+	for _, opt := range options {
+		opt(h)
+	}
+
+	return h
+}
+
+// Gorm returns the default helper, the options change it for all its users.
+// Use NewGormHelper for an independent one.
 func Gorm(options ...func(*GormHelper)) *GormHelper {
 	gormHelperOnce.Do(func() {
-		gormHelper = &GormHelper{
-			Config: &gorm.Config{
-				Logger: NewGormZerologLogger(),
-			},
-		}
+		gormHelper = NewGormHelper()
 	})
 	for _, opt := range options {
 		opt(gormHelper)
@@ -56,6 +69,8 @@ type GormZerologLogger struct {
 	Level                     zerolog.Level
 	SlowThreshold             time.Duration
 	IgnoreRecordNotFoundError bool
+	// Redactor masks the values of the sensitive columns in the SQL logged and written to BackupWriter, nil to keep them
+	Redactor *Redactor
 }
 
 func NewGormZerologLogger(options ...func(logger *GormZerologLogger)) *GormZerologLogger {
@@ -63,6 +78,7 @@ func NewGormZerologLogger(options ...func(logger *GormZerologLogger)) *GormZerol
 		Level:                     zerolog.TraceLevel,
 		SlowThreshold:             200 * time.Millisecond,
 		IgnoreRecordNotFoundError: true,
+		Redactor:                  NewRedactor(),
 	}
 
 	for _, opt := range options {
@@ -74,7 +90,10 @@ func NewGormZerologLogger(options ...func(logger *GormZerologLogger)) *GormZerol
 
 func (l *GormZerologLogger) LogMode(level logger.LogLevel) logger.Interface {
 	newLogger := GormZerologLogger{
-		SlowThreshold: l.SlowThreshold,
+		BackupWriter:              l.BackupWriter,
+		SlowThreshold:             l.SlowThreshold,
+		IgnoreRecordNotFoundError: l.IgnoreRecordNotFoundError,
+		Redactor:                  l.Redactor,
 	}
 	switch level {
 	case logger.Silent:
@@ -111,6 +130,9 @@ func (l *GormZerologLogger) Trace(ctx context.Context, begin time.Time, fc func(
 
 	sql, rows := fc()
 	elapsed := time.Since(begin)
+	if l.Redactor != nil {
+		sql = l.Redactor.RedactSQL(sql)
+	}
 
 	var evt *zerolog.Event
 	if err != nil {
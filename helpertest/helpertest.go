@@ -0,0 +1,145 @@
+// Package helpertest runs the handlers of helper.GinRouter in-process,
+// it sends typed requests and decodes typed responses without a server
+package helpertest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/fioepq9/helper"
+)
+
+// Kit is a router with its own GinHelper and engine, register the handlers on Router
+type Kit struct {
+	Helper *helper.GinHelper
+	Engine *gin.Engine
+	Router *helper.GinRouter
+}
+
+// New returns a kit with helper.NewGinHelper(options...) in gin.TestMode
+func New(options ...func(*helper.GinHelper)) *Kit {
+	gin.SetMode(gin.TestMode)
+	k := &Kit{
+		Helper: helper.NewGinHelper(options...),
+		Engine: gin.New(),
+	}
+	k.Router = k.Helper.Router(k.Engine)
+	return k
+}
+
+// Do sends the request built by NewRequest to the engine, the body is encoded for the bindings of the route
+// registered for method and path. The options change the request before it is sent.
+func (k *Kit) Do(method, path string, req any, options ...func(*http.Request)) *Response {
+	r, err := NewRequest(method, path, req, k.bodyBinding(method, path))
+	if err != nil {
+		panic(err)
+	}
+	for _, opt := range options {
+		opt(r)
+	}
+	w := httptest.NewRecorder()
+	k.Engine.ServeHTTP(w, r)
+	return &Response{ResponseRecorder: w, envelope: k.Helper.Envelope}
+}
+
+// bodyBinding returns the body binding of the route registered for method and path, "" if it is not registered
+func (k *Kit) bodyBinding(method, path string) string {
+	for _, route := range k.Router.Routes() {
+		if route.Method == method && route.Path == path {
+			return helper.GinClientBodyBinding(route.Bindings)
+		}
+	}
+	return ""
+}
+
+// Call sends req with Do and decodes the 2xx response into Resp, resp is nil for the other statuses
+func Call[Resp any](k *Kit, method, path string, req any, options ...func(*http.Request)) (*Resp, *Response) {
+	res := k.Do(method, path, req, options...)
+	if res.Code < 200 || res.Code >= 300 || res.Body.Len() == 0 {
+		return nil, res
+	}
+	resp := new(Resp)
+	if err := res.Decode(resp); err != nil {
+		panic(err)
+	}
+	return resp, res
+}
+
+// NewRequest builds the request from the tags of the struct req with helper.NewGinClientRequest, nil for no fields.
+// bodyBinding is the binding which decodes the body, e.g. json, see helper.GinClientBodyBinding.
+//   - uri: replaces :name and *name in path
+//   - form: the query, zero values are omitted, or the urlencoded body for the form binding
+//   - header, cookie: zero values are omitted
+//   - json, xml, yaml, toml, msgpack: the body, sent for the methods other than GET and HEAD
+func NewRequest(method, path string, req any, bodyBinding string) (*http.Request, error) {
+	parts, err := helper.NewGinClientRequest(method, req, bodyBinding)
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader
+	if parts.Body != nil {
+		body = bytes.NewReader(parts.Body)
+	}
+	r := httptest.NewRequest(method, parts.Target(path), body)
+	for k, vs := range parts.Header {
+		r.Header[k] = vs
+	}
+	for _, c := range parts.Cookies {
+		r.AddCookie(c)
+	}
+	if body != nil {
+		r.Header.Set("Content-Type", parts.ContentType)
+	}
+	return r, nil
+}
+
+// WithHeader sets the header key of the request to value
+func WithHeader(key, value string) func(*http.Request) {
+	return func(r *http.Request) {
+		r.Header.Set(key, value)
+	}
+}
+
+// WithContext sends the request with ctx, e.g. to cancel it as a client which disconnects
+func WithContext(ctx context.Context) func(*http.Request) {
+	return func(r *http.Request) {
+		*r = *r.WithContext(ctx)
+	}
+}
+
+// WithCookie adds the cookie to the request
+func WithCookie(cookie *http.Cookie) func(*http.Request) {
+	return func(r *http.Request) {
+		r.AddCookie(cookie)
+	}
+}
+
+// WithBody replaces the body and the Content-Type of the request, e.g. a raw or malformed body,
+// the Content-Type is removed if contentType is empty
+func WithBody(contentType string, body io.Reader) func(*http.Request) {
+	return func(r *http.Request) {
+		r.Body = io.NopCloser(body)
+		// the length is known for the readers of http.NewRequest, unknown for the others
+		switch b := body.(type) {
+		case *bytes.Buffer:
+			r.ContentLength = int64(b.Len())
+		case *bytes.Reader:
+			r.ContentLength = int64(b.Len())
+		case *strings.Reader:
+			r.ContentLength = int64(b.Len())
+		default:
+			r.ContentLength = -1
+		}
+		if contentType == "" {
+			r.Header.Del("Content-Type")
+			return
+		}
+		r.Header.Set("Content-Type", contentType)
+	}
+}
@@ -0,0 +1,13 @@
+package helpertest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHelpertest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Helpertest Suite")
+}
@@ -0,0 +1,114 @@
+package helpertest_test
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+var _ = Describe("Checking Kit", func() {
+	type UpdateUserRequest struct {
+		ID    int      `uri:"id"`
+		Token string   `header:"X-Token" binding:"required"`
+		Theme string   `cookie:"theme"`
+		Tags  []string `form:"tags"`
+		Name  string   `json:"name" binding:"required,min=2"`
+	}
+
+	type User struct {
+		ID    int      `json:"id"`
+		Name  string   `json:"name"`
+		Theme string   `json:"theme"`
+		Tags  []string `json:"tags"`
+		Token string   `json:"token"`
+	}
+
+	register := func(k *helpertest.Kit) {
+		k.Router.PUT("/users/:id", func(c *gin.Context, req *UpdateUserRequest) (*User, error) {
+			if req.ID == 404 {
+				return nil, helper.NewHTTPError(http.StatusNotFound, "user not found")
+			}
+			c.Header("X-User", req.Name)
+			return &User{ID: req.ID, Name: req.Name, Theme: req.Theme, Tags: req.Tags, Token: req.Token}, nil
+		})
+	}
+
+	It("should send the typed request and decode the typed response", func() {
+		k := helpertest.New()
+		register(k)
+
+		user, res := helpertest.Call[User](k, http.MethodPut, "/users/:id", &UpdateUserRequest{
+			ID:    1,
+			Token: "t",
+			Theme: "dark",
+			Tags:  []string{"a", "b"},
+			Name:  "alice",
+		})
+		Expect(res).To(helpertest.HaveStatus(http.StatusOK))
+		Expect(res).To(helpertest.HaveHeader("X-User", "alice"))
+		Expect(user).To(Equal(&User{ID: 1, Name: "alice", Theme: "dark", Tags: []string{"a", "b"}, Token: "t"}))
+	})
+
+	It("should assert the structured errors", func() {
+		k := helpertest.New()
+		register(k)
+
+		user, res := helpertest.Call[User](k, http.MethodPut, "/users/:id", &UpdateUserRequest{ID: 404, Token: "t", Name: "alice"})
+		Expect(user).To(BeNil())
+		Expect(res).To(helpertest.HaveHTTPError(http.StatusNotFound, "user not found"))
+
+		res = k.Do(http.MethodPut, "/users/:id", &UpdateUserRequest{ID: 1, Name: "a"})
+		Expect(res).To(helpertest.HaveStatus(http.StatusBadRequest))
+		Expect(res).To(helpertest.HaveFieldError("X-Token", "required"))
+		Expect(res).To(helpertest.HaveFieldError("name", "min"))
+		Expect(res).NotTo(helpertest.HaveFieldError("id", "required"))
+	})
+
+	It("should unwrap the envelope", func() {
+		k := helpertest.New(func(h *helper.GinHelper) {
+			h.Envelope = helper.NewGinEnvelope()
+		})
+		register(k)
+
+		user, res := helpertest.Call[User](k, http.MethodPut, "/users/:id", &UpdateUserRequest{ID: 2, Token: "t", Name: "bob"})
+		Expect(res).To(helpertest.HaveStatus(http.StatusOK))
+		Expect(user.Name).To(Equal("bob"))
+
+		res = k.Do(http.MethodPut, "/users/:id", &UpdateUserRequest{ID: 404, Token: "t", Name: "bob"})
+		Expect(res).To(helpertest.HaveHTTPError(http.StatusNotFound, "user not found"))
+
+		res = k.Do(http.MethodPut, "/users/:id", &UpdateUserRequest{ID: 2, Name: "bob"}, func(r *http.Request) {
+			r.Header.Del("X-Token")
+		})
+		Expect(res).To(helpertest.HaveFieldError("X-Token", "required"))
+	})
+
+	It("should change the request with the options", func() {
+		k := helpertest.New()
+		register(k)
+
+		res := k.Do(http.MethodPut, "/users/:id", &UpdateUserRequest{ID: 1},
+			helpertest.WithHeader("X-Token", "t"),
+			helpertest.WithCookie(&http.Cookie{Name: "theme", Value: "dark"}),
+			helpertest.WithBody("application/json", strings.NewReader(`{"name":"alice"}`)),
+		)
+		Expect(res).To(helpertest.HaveStatus(http.StatusOK))
+		Expect(res.Body.String()).To(MatchJSON(`{"id":1,"name":"alice","theme":"dark","tags":null,"token":"t"}`))
+
+		res = k.Do(http.MethodPut, "/users/:id", &UpdateUserRequest{ID: 1, Token: "t"},
+			helpertest.WithBody("application/json", strings.NewReader(`{`)))
+		Expect(res).To(helpertest.HaveStatus(http.StatusBadRequest))
+	})
+
+	It("should match the field errors of the binding errors", func() {
+		err := &helper.ValidationError{Fields: []helper.FieldError{{Field: "name", Rule: "required"}}}
+		Expect(error(err)).To(helpertest.HaveFieldError("name", "required"))
+		Expect(error(err)).NotTo(helpertest.HaveFieldError("name", "min"))
+	})
+})
@@ -0,0 +1,64 @@
+package helpertest
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/onsi/gomega/gcustom"
+	"github.com/onsi/gomega/types"
+
+	"github.com/fioepq9/helper"
+)
+
+// HaveStatus succeeds if the *Response has status
+func HaveStatus(status int) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(r *Response) (bool, error) {
+		return r.Code == status, nil
+	}).WithTemplate("Expected status {{.Data}}, got {{.Actual.Code}} with body\n{{.Actual.Body.String}}", status)
+}
+
+// HaveHeader succeeds if the header key of the *Response is value
+func HaveHeader(key, value string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(r *Response) (bool, error) {
+		return r.Header().Get(key) == value, nil
+	}).WithTemplate("Expected header {{index .Data 0}} to be {{index .Data 1}}, got {{.Actual.Header}}", []string{key, value})
+}
+
+// HaveHTTPError succeeds if the *Response has status and the HTTPError message
+func HaveHTTPError(status int, message string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(r *Response) (bool, error) {
+		e, err := r.HTTPError()
+		if err != nil {
+			return false, err
+		}
+		return r.Code == status && e.Message == message, nil
+	}).WithTemplate("Expected HTTPError {{index .Data 0}} {{printf \"%q\" (index .Data 1)}}, got {{.Actual.Code}} with body\n{{.Actual.Body.String}}", []any{status, message})
+}
+
+// HaveFieldError succeeds if the field has an error of rule, the actual value can be
+//   - *Response: the field errors in the details of its HTTPError
+//   - error: the field errors of the *helper.ValidationError found by errors.As
+func HaveFieldError(field, rule string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(actual any) (bool, error) {
+		var fields []helper.FieldError
+		switch a := actual.(type) {
+		case *Response:
+			var err error
+			if fields, err = a.FieldErrors(); err != nil {
+				return false, err
+			}
+		case error:
+			var verr *helper.ValidationError
+			if !errors.As(a, &verr) {
+				return false, nil
+			}
+			fields = verr.Fields
+		default:
+			return false, errors.Newf("HaveFieldError expects a *Response or an error, got %T", actual)
+		}
+		for _, f := range fields {
+			if f.Field == field && f.Rule == rule {
+				return true, nil
+			}
+		}
+		return false, nil
+	}).WithTemplate("Expected {{.To}} have the field error {{.Data}}\n{{.FormattedActual}}", []string{field, rule})
+}
@@ -0,0 +1,79 @@
+package helpertest
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/fioepq9/helper"
+)
+
+// Response is the recorded response, the envelope of the helper is unwrapped by its methods
+type Response struct {
+	*httptest.ResponseRecorder
+	envelope *helper.GinEnvelope
+}
+
+// Decode decodes the JSON body, or its data field when the body is an envelope, into v
+func (r *Response) Decode(v any) error {
+	body := r.Body.Bytes()
+	if fields, ok := r.envelopeFields(); ok {
+		body = fields[r.envelope.DataField]
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.Wrapf(err, "decode response %s failed", r.Body.String())
+	}
+	return nil
+}
+
+// HTTPError decodes the body into the HTTPError of the non-2xx response, the details are kept as JSON values
+func (r *Response) HTTPError() (*helper.HTTPError, error) {
+	var envelope *helper.GinEnvelope
+	if _, ok := r.envelopeFields(); ok {
+		envelope = r.envelope
+	}
+	var e *helper.HTTPError
+	if !errors.As(helper.DecodeGinResponse(envelope, r.Code, r.Body.Bytes(), nil), &e) {
+		return nil, errors.Newf("status %d is not an error", r.Code)
+	}
+	return e, nil
+}
+
+// FieldErrors decodes the details of the HTTPError into the field errors of the ValidationError
+func (r *Response) FieldErrors() ([]helper.FieldError, error) {
+	e, err := r.HTTPError()
+	if err != nil {
+		return nil, err
+	}
+	if e.Details == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(e.Details)
+	if err != nil {
+		return nil, errors.Wrap(err, "encode details failed")
+	}
+	var fields []helper.FieldError
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Wrapf(err, "decode details %s failed", data)
+	}
+	return fields, nil
+}
+
+// envelopeFields returns the fields of the body if it is an envelope, which has the code and data fields
+func (r *Response) envelopeFields() (map[string]json.RawMessage, bool) {
+	if r.envelope == nil || r.envelope.DataField == "" {
+		return nil, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(r.Body.Bytes(), &fields); err != nil {
+		return nil, false
+	}
+	if _, ok := fields[r.envelope.DataField]; !ok {
+		return nil, false
+	}
+	if _, ok := fields[r.envelope.CodeField]; r.envelope.CodeField != "" && !ok {
+		return nil, false
+	}
+	return fields, true
+}
@@ -0,0 +1,214 @@
+package helper
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultMaxPageSize clamps the page size of the requests which do not implement PaginationSizer
+var DefaultMaxPageSize = 100
+
+// paginationDefaultSize is the default tag of Pagination.Size, it is the size of the Pagination which is not bound,
+// e.g. built in code
+var paginationDefaultSize = func() int {
+	f, _ := reflect.TypeOf(Pagination{}).FieldByName("Size")
+	size, err := strconv.Atoi(f.Tag.Get("default"))
+	if err != nil {
+		panic(err)
+	}
+	return size
+}()
+
+// Pagination is the pagination request embedded in the list requests, bound by the default and form bindings.
+// The page is given by page/size, or by limit/offset when either is set, e.g. ?page=2&size=10 or ?offset=10&limit=10.
+// Sort is a comma separated list of the fields, descending if prefixed with -, e.g. ?sort=-created_at,name,
+// the fields must be allowed by the PaginationSorter of the request.
+//
+//	type ListUsersRequest struct {
+//		helper.Pagination
+//		Name string `form:"name"`
+//	}
+type Pagination struct {
+	Page   int    `form:"page" json:"page,omitempty" default:"1" binding:"min=1"`
+	Size   int    `form:"size" json:"size,omitempty" default:"20" binding:"min=1"`
+	Limit  int    `form:"limit" json:"limit,omitempty" binding:"min=0"`
+	Offset int    `form:"offset" json:"offset,omitempty" binding:"min=0"`
+	Sort   string `form:"sort" json:"sort,omitempty"`
+}
+
+// Paginator is implemented by the requests embedding Pagination
+type Paginator interface {
+	pagination() *Pagination
+}
+
+// PaginationSorter is implemented by the requests embedding Pagination which can be sorted,
+// SortFields returns the allowed sort fields, which are the column names.
+// The requests which do not implement it reject any sort.
+type PaginationSorter interface {
+	SortFields() []string
+}
+
+// PaginationSizer is implemented by the requests embedding Pagination which clamp the page size to another maximum
+type PaginationSizer interface {
+	MaxPageSize() int
+}
+
+// PaginationOrder is a sort field of Pagination
+type PaginationOrder struct {
+	Field string
+	Desc  bool
+}
+
+func (p *Pagination) pagination() *Pagination {
+	return p
+}
+
+// Orders returns the sort fields in order
+func (p *Pagination) Orders() []PaginationOrder {
+	var orders []PaginationOrder
+	for _, field := range strings.Split(p.Sort, ",") {
+		field = strings.TrimSpace(field)
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimLeft(field, "+-")
+		if field == "" {
+			continue
+		}
+		orders = append(orders, PaginationOrder{Field: field, Desc: desc})
+	}
+	return orders
+}
+
+// Window returns the offset and the limit of the page, the limit is clamped to maxSize if it is positive
+func (p *Pagination) Window(maxSize int) (offset, limit int) {
+	limit = p.Size
+	if p.Limit > 0 {
+		limit = p.Limit
+	}
+	if limit <= 0 {
+		limit = paginationDefaultSize
+	}
+	if maxSize > 0 && limit > maxSize {
+		limit = maxSize
+	}
+	if p.Limit > 0 || p.Offset > 0 {
+		return max(p.Offset, 0), limit
+	}
+	return (max(p.Page, 1) - 1) * limit, limit
+}
+
+// paginationWindow returns the window of the Pagination of req, clamped to its PaginationSizer or DefaultMaxPageSize
+func paginationWindow(req Paginator) (offset, limit int) {
+	maxSize := DefaultMaxPageSize
+	if sizer, ok := req.(PaginationSizer); ok {
+		maxSize = sizer.MaxPageSize()
+	}
+	return req.pagination().Window(maxSize)
+}
+
+// Paginate returns the gorm scope which orders, offsets and limits the query by the Pagination of req,
+// e.g. db.Scopes(helper.Paginate(req)).Find(&users)
+func Paginate(req Paginator) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		offset, limit := paginationWindow(req)
+		// the columns are quoted, the fields are validated by PaginationSorter when the request is bound
+		for _, o := range req.pagination().Orders() {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: o.Field}, Desc: o.Desc})
+		}
+		return db.Offset(offset).Limit(limit)
+	}
+}
+
+// Page is the page response of the list requests
+type Page[T any] struct {
+	Items []T   `json:"items"`
+	Total int64 `json:"total"`
+	// Page and Size are the page/size of the window, Page is 0 when the offset is not a multiple of the size
+	Page   int `json:"page,omitempty"`
+	Size   int `json:"size"`
+	Offset int `json:"offset"`
+	// HasNext reports whether there are more items after the page, NextPage and NextOffset give the next page
+	HasNext    bool `json:"has_next"`
+	NextPage   int  `json:"next_page,omitempty"`
+	NextOffset int  `json:"next_offset,omitempty"`
+}
+
+// NewPage returns the page of items in the window of the Pagination of req, total is the count of all items
+func NewPage[T any](req Paginator, items []T, total int64) *Page[T] {
+	offset, limit := paginationWindow(req)
+	if items == nil {
+		items = make([]T, 0)
+	}
+	p := &Page[T]{
+		Items:  items,
+		Total:  total,
+		Size:   limit,
+		Offset: offset,
+	}
+	if offset%limit == 0 {
+		p.Page = offset/limit + 1
+	}
+	if next := offset + len(items); int64(next) < total && len(items) > 0 {
+		p.HasNext = true
+		p.NextOffset = next
+		if p.Page > 0 {
+			p.NextPage = p.Page + 1
+		}
+	}
+	return p
+}
+
+// RegisterPaginationValidation registers the validation of the sort fields of Pagination on v,
+// the BindingValidator of NewGinHelper has it, e.g. helper.NewGinValidator(helper.RegisterPaginationValidation)
+func RegisterPaginationValidation(v *GinValidator) {
+	v.Validate.RegisterStructValidation(validatePagination, Pagination{})
+}
+
+// validatePagination is the struct level validation of Pagination, it rejects the sort fields
+// which are not allowed by the PaginationSorter of the request embedding it
+func validatePagination(sl validator.StructLevel) {
+	p, ok := sl.Current().Interface().(Pagination)
+	if !ok {
+		return
+	}
+	orders := p.Orders()
+	if len(orders) == 0 {
+		return
+	}
+	var allowed []string
+	if sorter, ok := paginationSorter(sl.Parent()); ok {
+		allowed = sorter.SortFields()
+	}
+	for _, o := range orders {
+		found := false
+		for _, field := range allowed {
+			if o.Field == field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			sl.ReportError(p.Sort, "sort", "Sort", "oneof", strings.Join(allowed, " "))
+			return
+		}
+	}
+}
+
+// paginationSorter returns the PaginationSorter of the struct v, by value or by pointer
+func paginationSorter(v reflect.Value) (PaginationSorter, bool) {
+	if !v.IsValid() {
+		return nil, false
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		v = v.Addr()
+	}
+	if !v.CanInterface() {
+		return nil, false
+	}
+	sorter, ok := v.Interface().(PaginationSorter)
+	return sorter, ok
+}
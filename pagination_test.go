@@ -0,0 +1,128 @@
+package helper_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+type PaginationUsersRequest struct {
+	helper.Pagination
+	Name string `form:"name"`
+}
+
+func (*PaginationUsersRequest) SortFields() []string {
+	return []string{"name", "created_at"}
+}
+
+func (*PaginationUsersRequest) MaxPageSize() int {
+	return 50
+}
+
+type PaginationItemsRequest struct {
+	helper.Pagination
+}
+
+type PaginationUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var _ = Describe("Checking Pagination", Label("pagination"), func() {
+	var k *helpertest.Kit
+
+	BeforeEach(func() {
+		k = helpertest.New()
+		r := k.Router
+		r.GET("/users", func(c *gin.Context, req *PaginationUsersRequest) (*helper.Page[PaginationUser], error) {
+			users := []PaginationUser{{ID: 1, Name: req.Name}, {ID: 2, Name: req.Name}}
+			return helper.NewPage(req, users, 5), nil
+		})
+		r.GET("/items", func(c *gin.Context, req *PaginationItemsRequest) (*helper.Pagination, error) {
+			return &req.Pagination, nil
+		})
+	})
+
+	It("should bind the embedded pagination with the default values", func() {
+		w := k.Do(http.MethodGet, "/items", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"page":1,"size":20}`))
+
+		w = k.Do(http.MethodGet, "/items", &PaginationItemsRequest{Pagination: helper.Pagination{Offset: 10, Limit: 5}})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"page":1,"size":20,"offset":10,"limit":5}`))
+
+		w = k.Do(http.MethodGet, "/items?page=0", nil)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should respond the page with the next page info", func() {
+		var page helper.Page[PaginationUser]
+		w := k.Do(http.MethodGet, "/users", &PaginationUsersRequest{Pagination: helper.Pagination{Page: 2, Size: 2}, Name: "alice"})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(json.Unmarshal(w.Body.Bytes(), &page)).To(Succeed())
+		Expect(page).To(Equal(helper.Page[PaginationUser]{
+			Items:      []PaginationUser{{ID: 1, Name: "alice"}, {ID: 2, Name: "alice"}},
+			Total:      5,
+			Page:       2,
+			Size:       2,
+			Offset:     2,
+			HasNext:    true,
+			NextPage:   3,
+			NextOffset: 4,
+		}))
+
+		// the size is clamped to MaxPageSize
+		page = helper.Page[PaginationUser]{}
+		w = k.Do(http.MethodGet, "/users", &PaginationUsersRequest{Pagination: helper.Pagination{Size: 1000}})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(json.Unmarshal(w.Body.Bytes(), &page)).To(Succeed())
+		Expect(page.Size).To(Equal(50))
+		Expect(page.NextPage).To(Equal(2))
+
+		last := helper.NewPage(&PaginationItemsRequest{Pagination: helper.Pagination{Offset: 3, Limit: 2}}, []int{4, 5}, 5)
+		Expect(last.Page).To(BeZero())
+		Expect(last.HasNext).To(BeFalse())
+		empty := helper.NewPage[int](&PaginationItemsRequest{}, nil, 0)
+		Expect(empty.Items).To(BeEmpty())
+		// the Pagination which is not bound has the size of the default tag
+		Expect(empty.Size).To(Equal(20))
+	})
+
+	It("should validate the sort fields", func() {
+		Expect(k.Do(http.MethodGet, "/users", &PaginationUsersRequest{Pagination: helper.Pagination{Sort: "-created_at,name"}}).Code).To(Equal(http.StatusOK))
+		w := k.Do(http.MethodGet, "/users", &PaginationUsersRequest{Pagination: helper.Pagination{Sort: "password"}})
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring("sort"))
+		// the requests without SortFields can not be sorted
+		Expect(k.Do(http.MethodGet, "/items", &PaginationItemsRequest{Pagination: helper.Pagination{Sort: "id"}}).Code).To(Equal(http.StatusBadRequest))
+
+		// the validators without RegisterPaginationValidation do not check the sort fields
+		req := &PaginationUsersRequest{Pagination: helper.Pagination{Page: 1, Size: 1, Sort: "password"}}
+		Expect(helper.NewGinValidator().ValidateStruct(req)).To(Succeed())
+		Expect(helper.NewGinValidator(helper.RegisterPaginationValidation).ValidateStruct(req)).NotTo(Succeed())
+	})
+
+	It("should apply the pagination to the gorm query", func() {
+		db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{
+			DryRun:               true,
+			DisableAutomaticPing: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		req := &PaginationUsersRequest{Pagination: helper.Pagination{Page: 3, Size: 100, Sort: "-created_at,name"}}
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			var users []PaginationUser
+			return tx.Scopes(helper.Paginate(req)).Find(&users)
+		})
+		Expect(sql).To(Equal(
+			"SELECT * FROM `pagination_users` ORDER BY `created_at` DESC,`name` LIMIT 50 OFFSET 100"))
+	})
+})
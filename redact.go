@@ -0,0 +1,567 @@
+package helper
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DefaultRedactPattern matches the keys and columns of the passwords, secrets, tokens and ID numbers.
+// It matches whole segments of the snake_case keys, so valid_number and tokens_used are not sensitive,
+// and cookie only as the last segment, so set_cookie is sensitive but cookie_consent is not.
+var DefaultRedactPattern = regexp.MustCompile(
+	`(?i)(^|_)(passw(or)?ds?|pwd|secrets?|token|api_?key|authorization|credentials?|id_?(card|number)|credit_?card|cvv|ssn)($|_)` +
+		`|(^|_)cookie$`)
+
+// Redactor masks the sensitive values in the logged payloads, paths and SQL.
+// A value is sensitive if its struct field has the tag redact:"true", or its field name, json name,
+// map key, path parameter or column matches Patterns; redact:"false" opts a field out of Patterns.
+// It does not depend on the log level, the payloads logged at the debug and trace levels are masked too.
+type Redactor struct {
+	// Patterns match the sensitive keys and columns converted to snake_case, defaults to DefaultRedactPattern
+	Patterns []*regexp.Regexp
+	// Mask replaces the sensitive strings, defaults to ***, the sensitive values of other types are zeroed
+	Mask string
+
+	fields sync.Map // reflect.Type -> []bool, the sensitive fields of the struct type
+}
+
+// redactMaxDepth stops redacting the values nested deeper, e.g. the cyclic pointers
+const redactMaxDepth = 32
+
+func NewRedactor(options ...func(*Redactor)) *Redactor {
+	r := &Redactor{
+		Patterns: []*regexp.Regexp{DefaultRedactPattern},
+		Mask:     "***",
+	}
+
+	for _, opt := range options {
+		opt(r)
+	}
+
+	return r
+}
+
+// Sensitive reports whether key matches one of Patterns,
+// key is converted to snake_case first, e.g. AccessToken to access_token and X-Api-Key to x_api_key
+func (r *Redactor) Sensitive(key string) bool {
+	key = redactSnakeCase(key)
+	for _, p := range r.Patterns {
+		if p.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSnakeCase converts the camelCase, kebab-case and dotted key to lower snake_case,
+// the acronyms stay one word, e.g. IDNumber to id_number and APIKey to api_key
+func redactSnakeCase(key string) string {
+	var b strings.Builder
+	b.Grow(len(key) + 4)
+	runes := []rune(key)
+	for i, c := range runes {
+		switch {
+		case c == '-' || c == '.' || c == ' ':
+			b.WriteByte('_')
+		case unicode.IsUpper(c):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(c))
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// Redact returns v with the sensitive values masked, v itself is not modified.
+// The structs, maps and slices holding sensitive values are copied, others are returned as is.
+func (r *Redactor) Redact(v any) any {
+	if v == nil {
+		return nil
+	}
+	rv, ok := r.redact(reflect.ValueOf(v), 0)
+	if !ok {
+		return v
+	}
+	return rv.Interface()
+}
+
+// redact returns the copy of v with the sensitive values masked, false if v has none
+func (r *Redactor) redact(v reflect.Value, depth int) (reflect.Value, bool) {
+	if depth > redactMaxDepth {
+		return v, false
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, ok := r.redact(v.Elem(), depth+1)
+		if !ok {
+			return v, false
+		}
+		p := reflect.New(v.Type().Elem())
+		p.Elem().Set(elem)
+		return p, true
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, ok := r.redact(v.Elem(), depth+1)
+		if !ok {
+			return v, false
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(elem)
+		return out, true
+	case reflect.Struct:
+		return r.redactStruct(v, depth)
+	case reflect.Slice, reflect.Array:
+		if !redactable(v.Type().Elem()) {
+			return v, false
+		}
+		var out reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := r.redact(v.Index(i), depth+1)
+			if !ok {
+				continue
+			}
+			if !out.IsValid() {
+				out = redactCopy(v)
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, out.IsValid()
+	case reflect.Map:
+		return r.redactMap(v, depth)
+	default:
+		return v, false
+	}
+}
+
+func (r *Redactor) redactStruct(v reflect.Value, depth int) (reflect.Value, bool) {
+	sensitive := r.sensitiveFields(v.Type())
+	var out reflect.Value
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		var (
+			nv reflect.Value
+			ok bool
+		)
+		if sensitive[i] {
+			nv, ok = r.masked(fv)
+		} else {
+			nv, ok = r.redact(fv, depth+1)
+		}
+		if !ok {
+			continue
+		}
+		if !out.IsValid() {
+			out = reflect.New(v.Type()).Elem()
+			out.Set(v)
+		}
+		out.Field(i).Set(nv)
+	}
+	return out, out.IsValid()
+}
+
+func (r *Redactor) redactMap(v reflect.Value, depth int) (reflect.Value, bool) {
+	if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+		return v, false
+	}
+	changed := make(map[int]reflect.Value)
+	keys := v.MapKeys()
+	for i, key := range keys {
+		var (
+			nv reflect.Value
+			ok bool
+		)
+		if r.Sensitive(key.String()) {
+			nv, ok = r.masked(v.MapIndex(key))
+		} else if redactable(v.Type().Elem()) {
+			nv, ok = r.redact(v.MapIndex(key), depth+1)
+		}
+		if ok {
+			changed[i] = nv
+		}
+	}
+	if len(changed) == 0 {
+		return v, false
+	}
+	out := reflect.MakeMapWithSize(v.Type(), v.Len())
+	for i, key := range keys {
+		if nv, ok := changed[i]; ok {
+			out.SetMapIndex(key, nv)
+			continue
+		}
+		out.SetMapIndex(key, v.MapIndex(key))
+	}
+	return out, true
+}
+
+// masked returns the mask of v, the Mask for strings and the zero value for others, false if v is zero
+func (r *Redactor) masked(v reflect.Value) (reflect.Value, bool) {
+	if v.IsZero() {
+		return v, false
+	}
+	t := v.Type()
+	switch {
+	case t.Kind() == reflect.String:
+		return reflect.ValueOf(r.Mask).Convert(t), true
+	case t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.String:
+		p := reflect.New(t.Elem())
+		p.Elem().Set(reflect.ValueOf(r.Mask).Convert(t.Elem()))
+		return p, true
+	case t.Kind() == reflect.Interface && reflect.TypeOf(r.Mask).Implements(t):
+		out := reflect.New(t).Elem()
+		out.Set(reflect.ValueOf(r.Mask))
+		return out, true
+	default:
+		return reflect.Zero(t), true
+	}
+}
+
+// sensitiveFields returns whether the fields of the struct type t are sensitive
+func (r *Redactor) sensitiveFields(t reflect.Type) []bool {
+	if v, ok := r.fields.Load(t); ok {
+		return v.([]bool)
+	}
+	sensitive := make([]bool, t.NumField())
+	for i := range sensitive {
+		f := t.Field(i)
+		if tag, ok := f.Tag.Lookup("redact"); ok {
+			sensitive[i], _ = strconv.ParseBool(tag)
+			continue
+		}
+		if f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		sensitive[i] = r.Sensitive(f.Name) || name != "" && r.Sensitive(name)
+	}
+	r.fields.Store(t, sensitive)
+	return sensitive
+}
+
+// redactable reports whether the values of t may hold sensitive values
+func redactable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// redactCopy returns the settable copy of the slice or array v
+func redactCopy(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Array {
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		return out
+	}
+	out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(out, v)
+	return out
+}
+
+// RedactPath masks the segments of path matching the sensitive parameters of the route template,
+// e.g. /reset/abc with /reset/:token is /reset/***
+func (r *Redactor) RedactPath(template, path string) string {
+	if !strings.ContainsAny(template, ":*") {
+		return path
+	}
+	names := strings.Split(template, "/")
+	segments := strings.Split(path, "/")
+	for i, name := range names {
+		if i >= len(segments) {
+			break
+		}
+		switch {
+		case strings.HasPrefix(name, ":") && r.Sensitive(name[1:]):
+			segments[i] = r.Mask
+		case strings.HasPrefix(name, "*") && r.Sensitive(name[1:]):
+			return strings.Join(append(segments[:i], r.Mask), "/")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// RedactSQL masks the values of the sensitive columns in the interpolated sql,
+// the values compared with the columns, e.g. password = 'x', and the values inserted into the columns
+func (r *Redactor) RedactSQL(sql string) string {
+	// the statements without sensitive words are not lexed
+	words := strings.FieldsFunc(sql, func(c rune) bool {
+		return c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+	sensitive := false
+	for _, word := range words {
+		if sensitive = r.Sensitive(word); sensitive {
+			break
+		}
+	}
+	if !sensitive {
+		return sql
+	}
+	tokens := lexSQL(sql)
+	var spans [][2]int
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.is("INSERT") || tok.is("REPLACE"):
+			var inserted [][2]int
+			inserted, i = r.insertedSQL(sql, tokens, i)
+			spans = append(spans, inserted...)
+		case tok.kind == sqlWord || tok.kind == sqlQuoted:
+			j := comparedSQL(tokens, i)
+			if j < 0 || !r.Sensitive(sqlColumn(sql[tok.start:tok.end])) {
+				continue
+			}
+			if span, ok := sqlValue(tokens, j); ok {
+				spans = append(spans, span)
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return sql
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		if span[0] < last {
+			continue
+		}
+		b.WriteString(sql[last:span[0]])
+		if sql[span[0]] == '(' {
+			b.WriteString("('" + r.Mask + "')")
+		} else {
+			b.WriteString("'" + r.Mask + "'")
+		}
+		last = span[1]
+	}
+	b.WriteString(sql[last:])
+	return b.String()
+}
+
+// insertedSQL returns the spans of the values inserted into the sensitive columns by the statement at tokens[i],
+// INSERT INTO t (a, b) VALUES (1, 2), (3, 4), and the index of its last token
+func (r *Redactor) insertedSQL(sql string, tokens []sqlToken, i int) ([][2]int, int) {
+	// the column list
+	for i < len(tokens) && !tokens[i].isPunct(sql, "(") {
+		if tokens[i].is("VALUES") || tokens[i].is("SELECT") || tokens[i].is("SET") {
+			return nil, i
+		}
+		i++
+	}
+	var sensitive []bool
+	for i++; i < len(tokens) && !tokens[i].isPunct(sql, ")"); i++ {
+		if tokens[i].kind == sqlWord || tokens[i].kind == sqlQuoted {
+			sensitive = append(sensitive, r.Sensitive(sqlColumn(sql[tokens[i].start:tokens[i].end])))
+		}
+	}
+	i++
+	if i >= len(tokens) || !(tokens[i].is("VALUES") || tokens[i].is("VALUE")) {
+		return nil, i - 1
+	}
+
+	// the value lists
+	var spans [][2]int
+	for i++; i < len(tokens) && tokens[i].isPunct(sql, "("); i++ {
+		column, depth, start := 0, 0, -1
+		for ; i < len(tokens); i++ {
+			tok := tokens[i]
+			switch {
+			case tok.isPunct(sql, "("):
+				depth++
+				if depth == 1 {
+					continue
+				}
+			case tok.isPunct(sql, ")"):
+				depth--
+			case tok.isPunct(sql, ",") && depth == 1:
+				column, start = column+1, -1
+				continue
+			}
+			if depth == 0 {
+				break
+			}
+			if column < len(sensitive) && sensitive[column] {
+				if start < 0 {
+					start = tok.start
+					spans = append(spans, [2]int{start, tok.end})
+				} else {
+					spans[len(spans)-1][1] = tok.end
+				}
+			}
+		}
+		// the comma between the value lists
+		if i+1 < len(tokens) && tokens[i+1].isPunct(sql, ",") {
+			i++
+			continue
+		}
+		break
+	}
+	return spans, i
+}
+
+// comparedSQL returns the index of the value compared with the column tokens[i], -1 if it is not compared
+func comparedSQL(tokens []sqlToken, i int) int {
+	j := i + 1
+	if j < len(tokens) && tokens[j].is("NOT") {
+		j++
+	}
+	if j >= len(tokens) {
+		return -1
+	}
+	switch tok := tokens[j]; {
+	case tok.kind == sqlOperator, tok.is("LIKE"), tok.is("IN"):
+		return j + 1
+	default:
+		return -1
+	}
+}
+
+// sqlValue returns the span of the value or the value list at tokens[j]
+func sqlValue(tokens []sqlToken, j int) ([2]int, bool) {
+	if j >= len(tokens) {
+		return [2]int{}, false
+	}
+	tok := tokens[j]
+	switch tok.kind {
+	case sqlPunct:
+		if tok.text != '(' {
+			return [2]int{}, false
+		}
+		depth := 0
+		for k := j; k < len(tokens); k++ {
+			switch tokens[k].text {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					return [2]int{tok.start, tokens[k].end}, true
+				}
+			}
+		}
+		return [2]int{}, false
+	case sqlWord:
+		// the placeholders and the function calls, e.g. VALUES(`password`)
+		if tok.end-tok.start == 1 && tok.text == '?' {
+			return [2]int{}, false
+		}
+		if j+1 < len(tokens) && tokens[j+1].text == '(' {
+			return [2]int{}, false
+		}
+	}
+	return [2]int{tok.start, tok.end}, true
+}
+
+// sqlColumn returns the unquoted column name of the identifier, e.g. password of `users`.`password`
+func sqlColumn(ident string) string {
+	if i := strings.LastIndexByte(ident, '.'); i >= 0 {
+		ident = ident[i+1:]
+	}
+	return strings.Trim(ident, "`\"[]")
+}
+
+const (
+	sqlWord = iota
+	sqlString
+	sqlQuoted
+	sqlOperator
+	sqlPunct
+)
+
+type sqlToken struct {
+	kind       int
+	start, end int
+	// text is the first byte of the token
+	text byte
+	// upper is the upper case word
+	upper string
+}
+
+func (t sqlToken) is(word string) bool {
+	return t.kind == sqlWord && t.upper == word
+}
+
+func (t sqlToken) isPunct(sql string, punct string) bool {
+	return t.kind == sqlPunct && sql[t.start:t.end] == punct
+}
+
+// lexSQL splits sql into the words, the string literals, the double-quoted identifiers or strings,
+// the comparison operators and the punctuations
+func lexSQL(sql string) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		start := i
+		var kind int
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '\'' || c == '"':
+			kind = sqlString
+			if c == '"' {
+				kind = sqlQuoted
+			}
+			for i++; i < len(sql); i++ {
+				if sql[i] == '\\' {
+					i++
+					continue
+				}
+				if sql[i] == c {
+					// the doubled quote is escaped
+					if i+1 < len(sql) && sql[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			i = min(i+1, len(sql))
+		case c == '`':
+			kind = sqlWord
+			if end := strings.IndexByte(sql[i+1:], '`'); end >= 0 {
+				i += end + 2
+			} else {
+				i = len(sql)
+			}
+		case strings.IndexByte("=<>!", c) >= 0:
+			kind = sqlOperator
+			for i < len(sql) && strings.IndexByte("=<>!", sql[i]) >= 0 {
+				i++
+			}
+		case strings.IndexByte("(),;", c) >= 0:
+			kind = sqlPunct
+			i++
+		default:
+			kind = sqlWord
+			for i < len(sql) && strings.IndexByte(" \t\n\r'\"`=<>!(),;", sql[i]) < 0 {
+				i++
+			}
+		}
+		tok := sqlToken{kind: kind, start: start, end: i, text: c}
+		if kind == sqlWord {
+			tok.upper = strings.ToUpper(sql[start:i])
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
@@ -0,0 +1,183 @@
+package helper_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/fioepq9/helper"
+	"github.com/fioepq9/helper/helpertest"
+)
+
+type RedactProfile struct {
+	Phone  string `json:"phone" redact:"true"`
+	Avatar string `json:"avatar"`
+}
+
+type RedactUser struct {
+	Name        string            `json:"name"`
+	Password    string            `json:"password"`
+	AccessToken string            `json:"-"`
+	TokenCount  int               `json:"token_count" redact:"false"`
+	IDNumber    *string           `json:"id_number,omitempty"`
+	Profile     *RedactProfile    `json:"profile"`
+	Profiles    []RedactProfile   `json:"profiles"`
+	Extra       map[string]any    `json:"extra"`
+	Headers     map[string]string `json:"headers"`
+}
+
+var _ = Describe("Checking Redactor", Label("redact"), func() {
+	var r *helper.Redactor
+
+	BeforeEach(func() {
+		r = helper.NewRedactor()
+	})
+
+	It("should mask the sensitive fields and keys", func() {
+		id := "110101199001011234"
+		user := &RedactUser{
+			Name:        "alice",
+			Password:    "p@ss",
+			AccessToken: "t",
+			TokenCount:  3,
+			IDNumber:    &id,
+			Profile:     &RedactProfile{Phone: "123", Avatar: "a.png"},
+			Profiles:    []RedactProfile{{Phone: "456"}},
+			Extra:       map[string]any{"api_key": "k", "nested": map[string]any{"secret": 1}, "cookie_consent": true},
+			Headers:     map[string]string{"Authorization": "Bearer t", "Accept": "*/*"},
+		}
+		redacted, ok := r.Redact(user).(*RedactUser)
+		Expect(ok).To(BeTrue())
+		Expect(redacted.Name).To(Equal("alice"))
+		Expect(redacted.Password).To(Equal("***"))
+		Expect(redacted.AccessToken).To(Equal("***"))
+		Expect(redacted.TokenCount).To(Equal(3))
+		Expect(*redacted.IDNumber).To(Equal("***"))
+		Expect(*redacted.Profile).To(Equal(RedactProfile{Phone: "***", Avatar: "a.png"}))
+		Expect(redacted.Profiles).To(Equal([]RedactProfile{{Phone: "***"}}))
+		Expect(redacted.Extra).To(Equal(map[string]any{
+			"api_key": "***", "nested": map[string]any{"secret": "***"}, "cookie_consent": true,
+		}))
+		Expect(redacted.Headers).To(Equal(map[string]string{"Authorization": "***", "Accept": "*/*"}))
+
+		// the original is not modified
+		Expect(user.Password).To(Equal("p@ss"))
+		Expect(*user.IDNumber).To(Equal(id))
+		Expect(user.Profile.Phone).To(Equal("123"))
+		Expect(user.Profiles[0].Phone).To(Equal("456"))
+		Expect(user.Extra["api_key"]).To(Equal("k"))
+
+		// the values without sensitive fields are returned as is
+		profile := &RedactProfile{Avatar: "a.png"}
+		Expect(r.Redact(profile)).To(BeIdenticalTo(profile))
+	})
+
+	DescribeTable("should match the whole segments of the snake_case keys",
+		func(key string, sensitive bool) {
+			Expect(r.Sensitive(key)).To(Equal(sensitive))
+		},
+		Entry(nil, "password", true),
+		Entry(nil, "AccessToken", true),
+		Entry(nil, "IDNumber", true),
+		Entry(nil, "X-Api-Key", true),
+		Entry(nil, "client_secret", true),
+		Entry(nil, "Set-Cookie", true),
+		Entry(nil, "valid_number", false),
+		Entry(nil, "tokens_used", false),
+		Entry(nil, "cookie_consent", false),
+		Entry(nil, "Tokenizer", false),
+	)
+
+	It("should mask the sensitive columns in the SQL", func() {
+		Expect(r.RedactSQL("SELECT * FROM `users` WHERE `users`.`password` = 'it''s' AND name = 'password'")).
+			To(Equal("SELECT * FROM `users` WHERE `users`.`password` = '***' AND name = 'password'"))
+		Expect(r.RedactSQL(`UPDATE "users" SET "token"='a\'b',"age"=18 WHERE id_number IN ('1','2') AND id = 1`)).
+			To(Equal(`UPDATE "users" SET "token"='***',"age"=18 WHERE id_number IN ('***') AND id = 1`))
+		Expect(r.RedactSQL("INSERT INTO `users` (`name`,`password`,`age`) VALUES ('alice','a,b',18),('bob',NULL,20) " +
+			"ON DUPLICATE KEY UPDATE `password`=VALUES(`password`)")).
+			To(Equal("INSERT INTO `users` (`name`,`password`,`age`) VALUES ('alice','***',18),('bob','***',20) " +
+				"ON DUPLICATE KEY UPDATE `password`=VALUES(`password`)"))
+		Expect(r.RedactSQL("SELECT * FROM `users` WHERE valid_number = 5 AND tokens_used = 3")).
+			To(Equal("SELECT * FROM `users` WHERE valid_number = 5 AND tokens_used = 3"))
+		Expect(r.RedactSQL("SELECT * FROM `users` WHERE name LIKE 'a%'")).
+			To(Equal("SELECT * FROM `users` WHERE name LIKE 'a%'"))
+	})
+
+	It("should mask the sensitive path parameters", func() {
+		Expect(r.RedactPath("/users/:id/reset/:token", "/users/1/reset/abc")).To(Equal("/users/1/reset/***"))
+		Expect(r.RedactPath("/files/*secret", "/files/a/b")).To(Equal("/files/***"))
+		Expect(r.RedactPath("/users/:id", "/users/1")).To(Equal("/users/1"))
+	})
+
+	It("should mask the payloads logged by Interface at the debug level", func() {
+		marshal := zerolog.InterfaceMarshalFunc
+		DeferCleanup(func() {
+			zerolog.InterfaceMarshalFunc = marshal
+		})
+		helper.NewZerologHelper().SetDefaultGlobalInterfaceMarshalFunc()
+
+		var buf bytes.Buffer
+		log := zerolog.New(&buf).Level(zerolog.DebugLevel)
+		log.Debug().Interface("user", RedactUser{Name: "alice", Password: "p@ss"}).Send()
+		Expect(buf.String()).To(ContainSubstring(`"password":"***"`))
+		Expect(buf.String()).NotTo(ContainSubstring("p@ss"))
+
+		buf.Reset()
+		helper.NewZerologHelper(func(h *helper.ZerologHelper) {
+			h.Redactor = nil
+		}).SetDefaultGlobalInterfaceMarshalFunc()
+		log.Debug().Interface("user", RedactUser{Password: "p@ss"}).Send()
+		Expect(buf.String()).To(ContainSubstring(`"password":"p@ss"`))
+	})
+
+	It("should mask the SQL of gorm in the logs and the backup", func() {
+		var logs, backup bytes.Buffer
+		l := helper.NewGormZerologLogger(func(l *helper.GormZerologLogger) {
+			l.BackupWriter = &backup
+			l.IgnoreRecordNotFoundError = false
+		})
+		ctx := zerolog.New(&logs).WithContext(context.Background())
+		sql := "SELECT * FROM `users` WHERE `password` = 'p@ss' LIMIT 1"
+		l.Trace(ctx, time.Now(), func() (string, int64) { return sql, 0 }, gorm.ErrRecordNotFound)
+
+		Expect(backup.String()).To(Equal("SELECT * FROM `users` WHERE `password` = '***' LIMIT 1;\n"))
+		m := make(map[string]any)
+		Expect(json.Unmarshal(logs.Bytes(), &m)).To(Succeed())
+		Expect(m).To(HaveKeyWithValue("sql", "SELECT * FROM `users` WHERE `password` = '***' LIMIT 1"))
+		Expect(m).To(HaveKeyWithValue("level", "error"))
+
+		// LogMode keeps the Redactor, BackupWriter and IgnoreRecordNotFoundError
+		logs.Reset()
+		backup.Reset()
+		l.LogMode(logger.Info).Trace(ctx, time.Now(), func() (string, int64) { return sql, 0 }, gorm.ErrRecordNotFound)
+		Expect(logs.String()).To(ContainSubstring("'***'"))
+		Expect(logs.String()).To(ContainSubstring(`"level":"error"`))
+		Expect(backup.String()).To(Equal("SELECT * FROM `users` WHERE `password` = '***' LIMIT 1;\n"))
+	})
+
+	It("should mask the path parameters in the access log", func() {
+		var buf bytes.Buffer
+		log := zerolog.New(&buf)
+		k := helpertest.New()
+		k.Engine.Use(k.Helper.AccessLog(func(l *helper.GinAccessLog) {
+			l.Logger = &log
+		}))
+		k.Router.GET("/reset/:token", func(c *gin.Context) error {
+			return nil
+		})
+		k.Do(http.MethodGet, "/reset/abc", nil)
+
+		m := make(map[string]any)
+		Expect(json.Unmarshal(buf.Bytes(), &m)).To(Succeed())
+		Expect(m).To(HaveKeyWithValue("path", "/reset/***"))
+	})
+})
@@ -26,34 +26,47 @@ type ViperHelper struct {
 	DecodeHooks   []mapstructure.DecodeHookFunc
 }
 
+// NewViperHelper returns an independent helper with its own viper.Viper, the options are applied after the defaults
+func NewViperHelper(options ...func(*ViperHelper)) *ViperHelper {
+	h := &ViperHelper{
+		V:          viper.New(),
+		ConfigFile: "etc/config.yaml",
+		TagName:    "yaml",
+		EnableEnv:  true,
+		IgnoreEnvFunc: func(key string, val string) bool {
+			if strings.HasPrefix(key, "_") {
+				return true
+			}
+			if strings.Contains(key, "__") {
+				return true
+			}
+			return false
+		},
+		DecodeHooks: []mapstructure.DecodeHookFunc{
+			mapstructure.StringToTimeDurationHookFunc(),
+			StringToSliceHookFunc(","),
+			mapstructure.OrComposeDecodeHookFunc(
+				mapstructure.StringToTimeHookFunc(time.RFC3339),
+				mapstructure.StringToTimeHookFunc(time.RFC3339Nano),
+			),
+			UnmarshalToStructHookFunc(yaml.Unmarshal),
+			UnmarshalToMapHookFunc(yaml.Unmarshal),
+			UnmarshalToSliceHookFunc(yaml.Unmarshal),
+		},
+	}
+
+	for _, opt := range options {
+		opt(h)
+	}
+
+	return h
+}
+
+// Viper returns the default helper, the options change it for all its users.
+// Use NewViperHelper for an independent one.
 func Viper(options ...func(*ViperHelper)) *ViperHelper {
 	viperHelperOnce.Do(func() {
-		viperHelper = &ViperHelper{
-			V:          viper.New(),
-			ConfigFile: "etc/config.yaml",
-			TagName:    "yaml",
-			EnableEnv:  true,
-			IgnoreEnvFunc: func(key string, val string) bool {
-				if strings.HasPrefix(key, "_") {
-					return true
-				}
-				if strings.Contains(key, "__") {
-					return true
-				}
-				return false
-			},
-			DecodeHooks: []mapstructure.DecodeHookFunc{
-				mapstructure.StringToTimeDurationHookFunc(),
-				StringToSliceHookFunc(","),
-				mapstructure.OrComposeDecodeHookFunc(
-					mapstructure.StringToTimeHookFunc(time.RFC3339),
-					mapstructure.StringToTimeHookFunc(time.RFC3339Nano),
-				),
-				UnmarshalToStructHookFunc(yaml.Unmarshal),
-				UnmarshalToMapHookFunc(yaml.Unmarshal),
-				UnmarshalToSliceHookFunc(yaml.Unmarshal),
-			},
-		}
+		viperHelper = NewViperHelper()
 	})
 	for _, opt := range options {
 		opt(viperHelper)
@@ -58,6 +58,21 @@ var _ = Describe("viper", Label("viper"), func() {
 		}))
 	})
 
+	It("new helpers are independent", func() {
+		v1 := helper.NewViperHelper(func(viperHelper *helper.ViperHelper) {
+			viperHelper.ConfigFile = ""
+			viperHelper.EnableEnv = false
+		})
+		v2 := helper.NewViperHelper()
+		Expect(v1.V).NotTo(BeIdenticalTo(v2.V))
+		Expect(v2.ConfigFile).To(Equal("etc/config.yaml"))
+		Expect(helper.Viper().ConfigFile).To(Equal("etc/config.yaml"))
+
+		var conf Config
+		Expect(v1.Unmarshal(&conf)).To(Succeed())
+		Expect(conf.Foo).To(BeEmpty())
+	})
+
 	It("bench", Serial, func() {
 		experiment := gmeasure.NewExperiment("viper - Benchmark")
 		AddReportEntry(experiment.Name, experiment)
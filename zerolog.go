@@ -18,11 +18,28 @@ var (
 	zerologHelperOnce sync.Once
 )
 
-type ZerologHelper struct{}
+type ZerologHelper struct {
+	// Redactor masks the sensitive values logged by Interface through the default interface marshal func, nil to log them as is
+	Redactor *Redactor
+}
+
+// NewZerologHelper returns a helper which does not touch the zerolog globals until its Set methods are called
+func NewZerologHelper(options ...func(*ZerologHelper)) *ZerologHelper {
+	h := &ZerologHelper{
+		Redactor: NewRedactor(),
+	}
+
+	for _, opt := range options {
+		opt(h)
+	}
 
+	return h
+}
+
+// Zerolog returns the default helper, its first call sets the default zerolog globals
 func Zerolog() *ZerologHelper {
 	zerologHelperOnce.Do(func() {
-		zerologHelper = &ZerologHelper{}
+		zerologHelper = NewZerologHelper()
 		zerologHelper.
 			SetDefaultGlobalLevel().
 			SetDefaultGlobalInterfaceMarshalFunc().
@@ -41,7 +58,11 @@ func (h *ZerologHelper) SetGlobalLevel(level zerolog.Level) *ZerologHelper {
 }
 
 func (h *ZerologHelper) SetDefaultGlobalInterfaceMarshalFunc() *ZerologHelper {
+	redactor := h.Redactor
 	return h.SetInterfaceMarshalFunc(func(v any) ([]byte, error) {
+		if redactor != nil {
+			v = redactor.Redact(v)
+		}
 		var buf bytes.Buffer
 		enc := json.NewEncoder(&buf)
 		enc.SetEscapeHTML(false)
@@ -49,7 +70,8 @@ func (h *ZerologHelper) SetDefaultGlobalInterfaceMarshalFunc() *ZerologHelper {
 		if err != nil {
 			return nil, err
 		}
-		return buf.Bytes(), nil
+		// Encode appends a newline, which splits the log line
+		return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
 	})
 }
 
@@ -59,32 +81,40 @@ func (h *ZerologHelper) SetInterfaceMarshalFunc(fn func(any) ([]byte, error)) *Z
 }
 
 func (h *ZerologHelper) SetDefaultGlobalErrorStackMarshaller() *ZerologHelper {
-	return h.SetErrorStackMarshaller(func(err error) any {
-		lastStackTraceErr := err
-		for ; err != nil; err = errors.Unwrap(err) {
-			_, ok := err.(errbase.StackTraceProvider)
-			if ok {
-				lastStackTraceErr = err
-			}
+	return h.SetErrorStackMarshaller(h.MarshalErrorStack)
+}
+
+// MarshalErrorStack returns the stack of the innermost cockroachdb error with stack in err,
+// it is the default zerolog.ErrorStackMarshaler
+func (h *ZerologHelper) MarshalErrorStack(err error) any {
+	return marshalErrorStack(err)
+}
+
+func marshalErrorStack(err error) any {
+	lastStackTraceErr := err
+	for ; err != nil; err = errors.Unwrap(err) {
+		_, ok := err.(errbase.StackTraceProvider)
+		if ok {
+			lastStackTraceErr = err
 		}
-		safeDetails := errors.GetSafeDetails(lastStackTraceErr).SafeDetails
-		if len(safeDetails) == 1 {
-			stackMsg, err := parsePII(safeDetails[0])
-			if err != nil {
-				return safeDetails[0]
-			}
-			return stackMsg
+	}
+	safeDetails := errors.GetSafeDetails(lastStackTraceErr).SafeDetails
+	if len(safeDetails) == 1 {
+		stackMsg, err := parsePII(safeDetails[0])
+		if err != nil {
+			return safeDetails[0]
 		}
-		res := make([][]StackInfo, 0)
-		for _, details := range safeDetails {
-			stackMsg, err := parsePII(details)
-			if err != nil {
-				return safeDetails
-			}
-			res = append(res, stackMsg)
+		return stackMsg
+	}
+	res := make([][]StackInfo, 0)
+	for _, details := range safeDetails {
+		stackMsg, err := parsePII(details)
+		if err != nil {
+			return safeDetails
 		}
-		return res
-	})
+		res = append(res, stackMsg)
+	}
+	return res
 }
 
 func (h *ZerologHelper) SetErrorStackMarshaller(fn func(error) any) *ZerologHelper {
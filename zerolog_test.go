@@ -0,0 +1,28 @@
+package helper_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/fioepq9/helper"
+)
+
+var _ = Describe("Checking Zerolog", Label("zerolog"), func() {
+	It("should not split the log line with the default interface marshal func", func() {
+		marshal := zerolog.InterfaceMarshalFunc
+		DeferCleanup(func() {
+			zerolog.InterfaceMarshalFunc = marshal
+		})
+		helper.NewZerologHelper().SetDefaultGlobalInterfaceMarshalFunc()
+
+		var buf bytes.Buffer
+		log := zerolog.New(&buf)
+		log.Info().Interface("user", map[string]string{"name": "<alice>"}).Str("next", "field").Send()
+		Expect(strings.Count(buf.String(), "\n")).To(Equal(1))
+		Expect(buf.String()).To(MatchJSON(`{"level":"info","user":{"name":"<alice>"},"next":"field"}`))
+	})
+})